@@ -0,0 +1,222 @@
+package qr
+
+import (
+	"errors"
+	"sort"
+)
+
+// StructuredAppend places a symbol in a sequence of up to 16 that hold the
+// data together, readers join their contents in order
+type StructuredAppend struct {
+  // Index is the position of the symbol in the sequence, from 0
+  Index int
+  // Total is the number of symbols in the sequence, 0 for a standalone
+  // symbol
+  Total int
+  // Parity is the XOR of every byte of the whole data, the same in all the
+  // symbols of the sequence
+  Parity byte
+}
+
+// maxAppendSymbols is the longest sequence the 4 bit total can count
+const maxAppendSymbols = 16
+
+// appendHeaderBits is the length of the header: 0011 mode indicator, index,
+// total minus 1 and parity
+const appendHeaderBits = 4 + 4 + 4 + 8
+
+// header is the bits that go before the first segment, none for a
+// standalone symbol
+func (a StructuredAppend) header() *BitBuffer {
+  buffer := &BitBuffer{}
+  if a.Total == 0 {
+    return buffer
+  }
+  buffer.AppendBits(0b0011, 4)
+  buffer.AppendBits(uint32(a.Index), 4)
+  buffer.AppendBits(uint32(a.Total - 1), 4)
+  buffer.AppendBits(uint32(a.Parity), 8)
+  return buffer
+}
+
+// EncodeStructured builds the symbols for text that may not fit in one.
+// Data that fits gives a single standalone symbol like Encode, longer data
+// is split in the fewest symbols possible (up to 16), all of the smallest
+// version that holds every part. Options.Version forces the version of the
+// parts. Only regular symbols without a logo can be chained
+func EncodeStructured(data string, options Options) ([]*Symbol, error) {
+  if err := options.validateStructured(); err != nil {
+    return nil, err
+  }
+
+  symbol, err := Encode(data, options)
+  if err == nil {
+    return []*Symbol{symbol}, nil
+  }
+  var tooLong *DataTooLongError
+  if !errors.As(err, &tooLong) {
+    return nil, err
+  }
+
+  return encodeParts(data, runeCuts(data), options)
+}
+
+// EncodeBytesStructured builds the symbols for binary data that may not fit
+// in one, all in byte mode. See EncodeStructured
+func EncodeBytesStructured(data []byte, options Options) ([]*Symbol, error) {
+  if err := options.validateStructured(); err != nil {
+    return nil, err
+  }
+
+  symbol, err := EncodeBytes(data, options)
+  if err == nil {
+    return []*Symbol{symbol}, nil
+  }
+  var tooLong *DataTooLongError
+  if !errors.As(err, &tooLong) {
+    return nil, err
+  }
+
+  options.Mode = Byte
+  cuts := make([]int, len(data))
+  for i := range cuts {
+    cuts[i] = i + 1
+  }
+  return encodeParts(string(data), cuts, options)
+}
+
+func (o Options) validateStructured() error {
+  if err := o.validate(); err != nil {
+    return err
+  }
+  if o.Micro || o.MaxHeight > 0 || o.LogoSize > 0 {
+    return ErrNoStructuredAppend
+  }
+  return nil
+}
+
+// encodeParts finds how many symbols the data needs in the biggest version,
+// then the smallest version that holds it in that many symbols, and encodes
+// each part with its header. cuts are the positions where a part can end
+func encodeParts(data string, cuts []int, options Options) ([]*Symbol, error) {
+  versions := []Version{}
+  if options.Version == 0 {
+    for _, v := range listVersions() {
+      if v.correction == options.Level {
+        versions = append(versions, v)
+      }
+    }
+  } else {
+    v, err := findVersion(options.Version, options.Level, false)
+    if err != nil {
+      return nil, err
+    }
+    versions = append(versions, v)
+  }
+
+  parts, ok := splitParts(data, cuts, versions[len(versions)-1], options.Mode, maxAppendSymbols)
+  if !ok {
+    return nil, ErrTooManySymbols
+  }
+  //bigger versions never need more symbols, the first one that fits in as
+  //many as the biggest is found by bisection
+  first := sort.Search(len(versions)-1, func(i int) bool {
+    _, ok := splitParts(data, cuts, versions[i], options.Mode, len(parts))
+    return ok
+  })
+  version := versions[first]
+  if first < len(versions)-1 {
+    parts, _ = splitParts(data, cuts, version, options.Mode, len(parts))
+  }
+
+  parity, err := segmentsParity(parts)
+  if err != nil {
+    return nil, err
+  }
+  symbols := make([]*Symbol, len(parts))
+  for i, segments := range parts {
+    symbols[i], err = encodeSymbol(segments, version, options, StructuredAppend{Index: i, Total: len(parts), Parity: parity})
+    if err != nil {
+      return nil, err
+    }
+  }
+  return symbols, nil
+}
+
+// splitParts fills each symbol of the version with the longest part of the
+// data that fits after the header, ok is false when it takes more than
+// maxParts symbols
+func splitParts(data string, cuts []int, version Version, mode EncodingMode, maxParts int) ([][]Segment, bool) {
+  parts := [][]Segment{}
+  start := 0
+  for len(cuts) > 0 {
+    if len(parts) == maxParts {
+      return nil, false
+    }
+    //no part is longer than the numeric capacity, the densest mode takes
+    //a byte per character
+    candidates := cuts[:sort.SearchInts(cuts, start + version.capNum + 1)]
+    end := sort.Search(len(candidates), func(i int) bool {
+      _, fits := partSegments(data[start:candidates[i]], version, mode)
+      return !fits
+    })
+    if end == 0 {
+      return nil, false
+    }
+    segments, _ := partSegments(data[start:candidates[end-1]], version, mode)
+    parts = append(parts, segments)
+    start = candidates[end-1]
+    cuts = cuts[end:]
+  }
+  return parts, true
+}
+
+// partSegments encodes a part in the given mode or the optimal segments,
+// fits tells if they leave room for the header in the version
+func partSegments(data string, version Version, mode EncodingMode) ([]Segment, bool) {
+  segments := []Segment{{mode: mode, data: data}}
+  if mode == AutoMode {
+    var err error
+    segments, err = optimizeSegments(data, version)
+    if err != nil {
+      return nil, false
+    }
+  }
+  return segments, appendHeaderBits + segmentsBits(segments, version) <= version.dataBits()
+}
+
+// segmentsParity XORs the bytes of the data as they are encoded, kanji
+// characters count with their Shift JIS bytes
+func segmentsParity(parts [][]Segment) (byte, error) {
+  var parity byte
+  for _, segments := range parts {
+    for _, segment := range segments {
+      if segment.mode != Kanji {
+        for i := 0; i < len(segment.data); i++ {
+          parity ^= segment.data[i]
+        }
+        continue
+      }
+      sjis, err := shiftJISKanji(segment.data)
+      if err != nil {
+        return 0, err
+      }
+      for _, value := range sjis {
+        parity ^= byte(value >> 8) ^ byte(value)
+      }
+    }
+  }
+  return parity, nil
+}
+
+// runeCuts lists the positions where text can be split, parts only end
+// between characters
+func runeCuts(data string) []int {
+  cuts := []int{}
+  for i := range data {
+    if i > 0 {
+      cuts = append(cuts, i)
+    }
+  }
+  return append(cuts, len(data))
+}
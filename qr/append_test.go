@@ -0,0 +1,66 @@
+package qr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructuredAppendHeader(t *testing.T) {
+  header := StructuredAppend{Index: 1, Total: 3, Parity: 0x78}.header()
+  if got, want := bitString(header), "0011" + "0001" + "0010" + "01111000"; got != want {
+    t.Errorf("header = %s, want %s", got, want)
+  }
+  if standalone := (StructuredAppend{}).header(); standalone.Len() != 0 {
+    t.Errorf("standalone header has %d bits", standalone.Len())
+  }
+}
+
+func TestEncodeStructuredErrors(t *testing.T) {
+  options := DefaultOptions()
+  options.Mode = Numeric
+  symbols, err := EncodeStructured("12a", options)
+  if err == nil || symbols != nil {
+    t.Errorf("got %v, %v; want no symbols and an error", symbols, err)
+  }
+
+  options = DefaultOptions()
+  options.Level = 'X'
+  symbols, err = EncodeBytesStructured([]byte("hello"), options)
+  if err != ErrInvalidLevel || symbols != nil {
+    t.Errorf("got %v, %v; want no symbols and ErrInvalidLevel", symbols, err)
+  }
+}
+
+func TestEncodeStructuredSplit(t *testing.T) {
+  data := strings.Repeat("x", 5000) + "!"
+  var parity byte
+  for i := 0; i < len(data); i++ {
+    parity ^= data[i]
+  }
+
+  symbols, err := EncodeStructured(data, DefaultOptions())
+  if err != nil {
+    t.Fatal(err)
+  }
+  //a 40-M symbol holds 2331 bytes
+  if len(symbols) != 3 {
+    t.Fatalf("got %d symbols, want 3", len(symbols))
+  }
+  for i, symbol := range symbols {
+    want := StructuredAppend{Index: i, Total: 3, Parity: parity}
+    if symbol.Append != want {
+      t.Errorf("symbol %d: %+v, want %+v", i, symbol.Append, want)
+    }
+    if symbol.Version != symbols[0].Version {
+      t.Errorf("symbol %d is version %d, the first one %d", i, symbol.Version, symbols[0].Version)
+    }
+  }
+
+  single, err := EncodeStructured("hello", DefaultOptions())
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(single) != 1 || single[0].Append.Total != 0 {
+    t.Errorf("short data should give one standalone symbol, got %d", len(single))
+  }
+}
@@ -0,0 +1,74 @@
+package qr
+
+// BitBuffer is an appendable sequence of bits, stored most significant bit
+// first. The last byte is padded with 0s
+type BitBuffer struct {
+  bytes []byte
+  length int
+}
+
+// Len is the number of bits written
+func (b *BitBuffer) Len() int {
+  return b.length
+}
+
+// Bytes returns the underlying bytes, a partial last byte is padded with 0s
+func (b *BitBuffer) Bytes() []byte {
+  return b.bytes
+}
+
+// Bit returns the bit at position i (0 is the first written)
+func (b *BitBuffer) Bit(i int) bool {
+  return (b.bytes[i/8] >> (7 - i%8)) & 1 == 1
+}
+
+func (b *BitBuffer) appendBit(bit bool) {
+  if b.length % 8 == 0 {
+    b.bytes = append(b.bytes, 0)
+  }
+  if bit {
+    b.bytes[b.length/8] |= 0x80 >> (b.length%8)
+  }
+  b.length++
+}
+
+// AppendBits writes the lowest width bits of value, most significant first
+func (b *BitBuffer) AppendBits(value uint32, width int) {
+  for bit := width-1; bit >= 0; bit-- {
+    b.appendBit((value >> bit) & 1 == 1)
+  }
+}
+
+// AppendBytes writes every byte in full
+func (b *BitBuffer) AppendBytes(data []byte) {
+  for _, value := range data {
+    b.AppendBits(uint32(value), 8)
+  }
+}
+
+// AppendBuffer writes the bits of other, ignoring its padding
+func (b *BitBuffer) AppendBuffer(other *BitBuffer) {
+  for i := 0; i < other.Len(); i++ {
+    b.appendBit(other.Bit(i))
+  }
+}
+
+// BitReader reads a BitBuffer sequentially
+type BitReader struct {
+  buffer *BitBuffer
+  pos int
+}
+
+func NewBitReader(buffer *BitBuffer) *BitReader {
+  return &BitReader{buffer: buffer}
+}
+
+// ReadBit returns the next bit, reading past the end returns false (0)
+func (r *BitReader) ReadBit() bool {
+  if r.pos >= r.buffer.Len() {
+    return false
+  }
+  bit := r.buffer.Bit(r.pos)
+  r.pos++
+  return bit
+}
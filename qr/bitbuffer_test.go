@@ -0,0 +1,80 @@
+package qr
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendBits(t *testing.T) {
+  tests := []struct {
+    value uint32
+    width int
+    bits string
+  }{
+    {0, 0, ""},
+    {1, 1, "1"},
+    {0b101, 3, "101"},
+    //only the lowest width bits are written
+    {0xFF, 4, "1111"},
+    {0b0011, 4, "0011"},
+    {0x1FF, 9, "111111111"},
+    {0xABCD, 16, "1010101111001101"},
+  }
+  for _, test := range tests {
+    buffer := &BitBuffer{}
+    buffer.AppendBits(test.value, test.width)
+    if got := bitString(buffer); got != test.bits {
+      t.Errorf("AppendBits(%b, %d) = %s, want %s", test.value, test.width, got, test.bits)
+    }
+  }
+}
+
+func TestAppendBitsPadding(t *testing.T) {
+  buffer := &BitBuffer{}
+  buffer.AppendBits(0b1, 1)
+  buffer.AppendBits(0b11, 10)
+  if buffer.Len() != 11 {
+    t.Fatalf("got %d bits, want 11", buffer.Len())
+  }
+  //the partial last byte is padded with 0s
+  if want := []byte{0b10000000, 0b01100000}; !bytes.Equal(buffer.Bytes(), want) {
+    t.Errorf("bytes = %08b, want %08b", buffer.Bytes(), want)
+  }
+}
+
+func TestAppendBuffer(t *testing.T) {
+  first := &BitBuffer{}
+  first.AppendBits(0b10110, 5)
+  second := &BitBuffer{}
+  second.AppendBits(0b110011001, 9)
+  second.AppendBytes([]byte{0xF0})
+
+  //the second buffer crosses the byte boundaries of the first one and its
+  //padding is left out
+  first.AppendBuffer(second)
+  if got, want := bitString(first), "10110" + "110011001" + "11110000"; got != want {
+    t.Errorf("got %s, want %s", got, want)
+  }
+  if want := []byte{0b10110110, 0b01100111, 0b11000000}; !bytes.Equal(first.Bytes(), want) {
+    t.Errorf("bytes = %08b, want %08b", first.Bytes(), want)
+  }
+}
+
+func TestBitReader(t *testing.T) {
+  buffer := &BitBuffer{}
+  buffer.AppendBits(0b101, 3)
+  reader := NewBitReader(buffer)
+
+  got := ""
+  for i := 0; i < 6; i++ {
+    if reader.ReadBit() {
+      got += "1"
+    } else {
+      got += "0"
+    }
+  }
+  //reading past the end gives 0s
+  if got != "101000" {
+    t.Errorf("got %s, want 101000", got)
+  }
+}
@@ -0,0 +1,167 @@
+package qr
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinContrast is the lowest symbol contrast accepted by default,
+// the limit of grade C in ISO/IEC 15415
+const DefaultMinContrast = 0.4
+
+// Colors are the colors of a rendered symbol.
+// Invert gives an inverted (light on dark) symbol, which not every reader
+// supports
+type Colors struct {
+  // Foreground is used for the dark modules, black when nil
+  Foreground color.Color
+  // Background is used for the light modules and the quiet zone, white
+  // when nil. It can be transparent
+  Background color.Color
+  // MinContrast makes rendering fail when the contrast of the colors is
+  // below it, 0 disables the check
+  MinContrast float64
+}
+
+// DefaultColors is black on white
+func DefaultColors() Colors {
+  return Colors{Foreground: color.Black, Background: color.White, MinContrast: DefaultMinContrast}
+}
+
+// Invert swaps the foreground and background, nil ones become the black
+// and white they stand for
+func (c Colors) Invert() Colors {
+  c.Foreground, c.Background = c.background(), c.foreground()
+  return c
+}
+
+func (c Colors) foreground() color.Color {
+  if c.Foreground == nil {
+    return color.Black
+  }
+  return c.Foreground
+}
+
+func (c Colors) background() color.Color {
+  if c.Background == nil {
+    return color.White
+  }
+  return c.Background
+}
+
+// check fails with a LowContrastError when the colors are under MinContrast
+func (c Colors) check() error {
+  if c.MinContrast <= 0 {
+    return nil
+  }
+  return CheckContrast(c.foreground(), c.background(), c.MinContrast)
+}
+
+// LowContrastError is returned when the colors are too similar for readers
+// to tell dark and light modules apart
+type LowContrastError struct {
+  Contrast float64
+  MinContrast float64
+}
+
+func (e *LowContrastError) Error() string {
+  return fmt.Sprintf("color contrast %.2f is below the minimum %.2f, the symbol may not scan", e.Contrast, e.MinContrast)
+}
+
+// Contrast is the symbol contrast of two colors: the difference of their
+// reflectance (relative luminance), from 0 to 1.
+// Translucent colors are evaluated over white, as if printed on paper
+func Contrast(a, b color.Color) float64 {
+  return math.Abs(reflectance(a) - reflectance(b))
+}
+
+// CheckContrast fails with a LowContrastError when the contrast is below min
+func CheckContrast(foreground, background color.Color, min float64) error {
+  contrast := Contrast(foreground, background)
+  if contrast < min {
+    return &LowContrastError{Contrast: contrast, MinContrast: min}
+  }
+  return nil
+}
+
+// reflectance is the relative luminance of the color composited over white
+func reflectance(c color.Color) float64 {
+  //premultiplied values, adding the uncovered part of the white under it
+  r, g, b, a := c.RGBA()
+  white := float64(0xFFFF - a)
+  linear := func(v uint32) float64 {
+    srgb := (float64(v) + white) / 0xFFFF
+    if srgb <= 0.04045 {
+      return srgb / 12.92
+    }
+    return math.Pow((srgb + 0.055) / 1.055, 2.4)
+  }
+  return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
+
+// ParseColor reads a color as hex (#rgb, #rrggbb or #rrggbbaa, the # is
+// optional), rgb(r, g, b), rgba(r, g, b, a) with alpha from 0 to 1, or
+// "transparent"
+func ParseColor(value string) (color.NRGBA, error) {
+  value = strings.TrimSpace(strings.ToLower(value))
+  invalid := fmt.Errorf("invalid color %q", value)
+
+  if value == "transparent" {
+    return color.NRGBA{}, nil
+  }
+
+  if strings.HasPrefix(value, "rgb(") || strings.HasPrefix(value, "rgba(") {
+    open := strings.Index(value, "(")
+    if !strings.HasSuffix(value, ")") {
+      return color.NRGBA{}, invalid
+    }
+    parts := strings.Split(value[open+1:len(value)-1], ",")
+    hasAlpha := strings.HasPrefix(value, "rgba(")
+    if (hasAlpha && len(parts) != 4) || (!hasAlpha && len(parts) != 3) {
+      return color.NRGBA{}, invalid
+    }
+    channels := [4]uint8{0, 0, 0, 255}
+    for i, part := range parts {
+      part = strings.TrimSpace(part)
+      if i == 3 {
+        alpha, err := strconv.ParseFloat(part, 64)
+        if err != nil || alpha < 0 || alpha > 1 {
+          return color.NRGBA{}, invalid
+        }
+        channels[3] = uint8(math.Round(alpha * 255))
+        continue
+      }
+      channel, err := strconv.ParseUint(part, 10, 8)
+      if err != nil {
+        return color.NRGBA{}, invalid
+      }
+      channels[i] = uint8(channel)
+    }
+    return color.NRGBA{R: channels[0], G: channels[1], B: channels[2], A: channels[3]}, nil
+  }
+
+  hex := strings.TrimPrefix(value, "#")
+  if len(hex) == 3 {
+    hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+  }
+  if len(hex) == 6 {
+    hex += "ff"
+  }
+  if len(hex) != 8 {
+    return color.NRGBA{}, invalid
+  }
+  rgba, err := strconv.ParseUint(hex, 16, 32)
+  if err != nil {
+    return color.NRGBA{}, invalid
+  }
+  return color.NRGBA{R: uint8(rgba >> 24), G: uint8(rgba >> 16), B: uint8(rgba >> 8), A: uint8(rgba)}, nil
+}
+
+// cssColor writes the color as #rrggbb and its opacity from 0 to 1
+func cssColor(c color.Color) (string, float64) {
+  nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+  return fmt.Sprintf("#%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B), float64(nrgba.A) / 255
+}
@@ -0,0 +1,122 @@
+package qr
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+  tests := []struct {
+    value string
+    want color.NRGBA
+  }{
+    {"#000", color.NRGBA{0, 0, 0, 255}},
+    {"#f80", color.NRGBA{0xFF, 0x88, 0x00, 255}},
+    {"1a2b3c", color.NRGBA{0x1A, 0x2B, 0x3C, 255}},
+    {"#1A2B3C", color.NRGBA{0x1A, 0x2B, 0x3C, 255}},
+    {"#1a2b3c80", color.NRGBA{0x1A, 0x2B, 0x3C, 0x80}},
+    {"  #FFFFFF00 ", color.NRGBA{0xFF, 0xFF, 0xFF, 0}},
+    {"rgb(10, 20, 30)", color.NRGBA{10, 20, 30, 255}},
+    {"rgba(10,20,30,0.5)", color.NRGBA{10, 20, 30, 128}},
+    {"RGBA(255, 0, 0, 0)", color.NRGBA{255, 0, 0, 0}},
+    {"rgba(0, 0, 0, 1)", color.NRGBA{0, 0, 0, 255}},
+    {"transparent", color.NRGBA{}},
+    {"Transparent", color.NRGBA{}},
+  }
+  for _, test := range tests {
+    got, err := ParseColor(test.value)
+    if err != nil {
+      t.Errorf("ParseColor(%q): %v", test.value, err)
+      continue
+    }
+    if got != test.want {
+      t.Errorf("ParseColor(%q) = %v, want %v", test.value, got, test.want)
+    }
+  }
+}
+
+func TestParseColorInvalid(t *testing.T) {
+  for _, value := range []string{
+    "",
+    "#",
+    "#12",
+    "#1234",
+    "#12345",
+    "#1234567",
+    "#ggg",
+    "red",
+    "rgb(1, 2)",
+    "rgb(1, 2, 3, 4)",
+    "rgba(1, 2, 3)",
+    "rgb(256, 0, 0)",
+    "rgb(-1, 0, 0)",
+    "rgb(1, 2, 3",
+    "rgba(1, 2, 3, 1.5)",
+    "rgba(1, 2, 3, x)",
+  } {
+    if got, err := ParseColor(value); err == nil {
+      t.Errorf("ParseColor(%q) = %v, want an error", value, got)
+    }
+  }
+}
+
+func TestContrast(t *testing.T) {
+  tests := []struct {
+    name string
+    a, b color.Color
+    want float64
+  }{
+    {"black on white", color.Black, color.White, 1},
+    {"same color", color.NRGBA{0x80, 0x80, 0x80, 255}, color.NRGBA{0x80, 0x80, 0x80, 255}, 0},
+    //translucent colors are composited over white
+    {"transparent over white", color.White, color.Transparent, 0},
+    {"transparent black over white", color.Black, color.NRGBA{0, 0, 0, 0}, 1},
+    {"half black is a gray", color.NRGBA{0, 0, 0, 128}, color.NRGBA{127, 127, 127, 255}, 0},
+  }
+  for _, test := range tests {
+    if got := Contrast(test.a, test.b); math.Abs(got - test.want) > 1e-3 {
+      t.Errorf("%s: contrast %.4f, want %.4f", test.name, got, test.want)
+    }
+    if got, reversed := Contrast(test.a, test.b), Contrast(test.b, test.a); got != reversed {
+      t.Errorf("%s: contrast %f one way and %f the other", test.name, got, reversed)
+    }
+  }
+}
+
+func TestCheckContrast(t *testing.T) {
+  //#cbcbcb on white is just over the minimum, #cccccc just under it
+  over := color.NRGBA{0xCB, 0xCB, 0xCB, 255}
+  under := color.NRGBA{0xCC, 0xCC, 0xCC, 255}
+  if err := CheckContrast(over, color.White, DefaultMinContrast); err != nil {
+    t.Errorf("#cbcbcb on white: %v", err)
+  }
+  err := CheckContrast(under, color.White, DefaultMinContrast)
+  var contrastErr *LowContrastError
+  if !errors.As(err, &contrastErr) {
+    t.Fatalf("#cccccc on white: got %v, want a LowContrastError", err)
+  }
+  if contrastErr.MinContrast != DefaultMinContrast || contrastErr.Contrast >= DefaultMinContrast {
+    t.Errorf("got %+v", contrastErr)
+  }
+
+  //translucent: half transparent #cbcbcb is lighter over white
+  if err := CheckContrast(color.NRGBA{0xCB, 0xCB, 0xCB, 128}, color.White, DefaultMinContrast); err == nil {
+    t.Error("translucent #cbcbcb on white passes the check")
+  }
+  if err := (Colors{Foreground: under, MinContrast: 0}).check(); err != nil {
+    t.Errorf("MinContrast 0 doesn't disable the check: %v", err)
+  }
+}
+
+func TestColorsInvert(t *testing.T) {
+  red := color.NRGBA{255, 0, 0, 255}
+  inverted := Colors{Foreground: red, MinContrast: 0.5}.Invert()
+  if inverted.Foreground != color.White || inverted.Background != red || inverted.MinContrast != 0.5 {
+    t.Errorf("got %+v", inverted)
+  }
+  if twice := DefaultColors().Invert().Invert(); twice != DefaultColors() {
+    t.Errorf("inverting twice gives %+v", twice)
+  }
+}
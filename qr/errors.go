@@ -0,0 +1,84 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+  ErrInvalidLevel = errors.New("invalid error correction level, use L, M, Q or H")
+  ErrInvalidVersion = errors.New("invalid version, use 1 to 40, or 1 to 4 for Micro QR")
+  ErrInvalidMask = errors.New("invalid mask pattern, use 0 to 7, or 0 to 3 for Micro QR")
+  ErrInvalidMode = errors.New("invalid encoding mode")
+  ErrInvalidImageOptions = errors.New("module size must be at least 1 and the quiet zone can't be negative")
+  ErrInvalidLogoSize = errors.New("invalid logo size, use a fraction of the symbol side between 0 and 1")
+  ErrLogoCoversFunction = errors.New("the logo would cover function patterns, make it smaller")
+  ErrSymbolTooLargeForLogo = errors.New("the symbol can't hold a logo, versions 7-13, 21-27 and 35-40 have an alignment pattern in the center")
+  ErrNoLogoArea = errors.New("the symbol has no area reserved for a logo, encode it with a LogoSize")
+  ErrInvalidStyle = errors.New("invalid style, finder rings can only be square or rounded")
+  ErrUnsupportedLevel = errors.New("the symbol doesn't support the error correction level, M1 only has L, M2 and M3 go up to M, M4 up to Q and rMQR only has M and H")
+  ErrInvalidHeight = errors.New("invalid rMQR height, use 7 to 17 modules without a version or Micro")
+  ErrUnsupportedMode = errors.New("the Micro QR version doesn't support the encoding mode, M1 only has numeric mode and M2 adds alphanumeric")
+  ErrNoStructuredAppend = errors.New("structured append only chains regular QR symbols without a logo")
+  ErrTooManySymbols = errors.New("data too long for a structured append sequence of 16 symbols")
+)
+
+// DataTooLongError is returned when the data doesn't fit in the symbol.
+// A lower correction level or a bigger version may make it fit
+type DataTooLongError struct {
+  Level CorrectionLevel
+  // Version is the version that was tried, 0 when none of them fit
+  Version int
+  // Micro tells if Version is a Micro QR version
+  Micro bool
+  // Modes are the encoding modes of the data, in order
+  Modes []EncodingMode
+  // Bits is the length of the encoded data
+  Bits int
+  // MaxBits is the capacity of the version, the biggest one for the level
+  // when Version is 0
+  MaxBits int
+}
+
+func (e *DataTooLongError) Error() string {
+  modes := make([]string, len(e.Modes))
+  for i, mode := range e.Modes {
+    modes[i] = mode.String()
+  }
+  target := fmt.Sprintf("level %c", e.Level)
+  if e.Version != 0 {
+    target = fmt.Sprintf("version %d-%c", e.Version, e.Level)
+    if e.Micro {
+      target = fmt.Sprintf("version M%d-%c", e.Version, e.Level)
+    }
+  }
+  return fmt.Sprintf("data too long for %s: %s needs %d bits, %d available", target, strings.Join(modes, "+"), e.Bits, e.MaxBits)
+}
+
+// LogoTooLargeError is returned when a logo hides more codewords of a block
+// than its error correction can recover, even at level H
+type LogoTooLargeError struct {
+  Level CorrectionLevel
+  // Codewords is the number of codewords hidden in the worst block
+  Codewords int
+  // Budget is the number of codewords each block can recover
+  Budget int
+}
+
+func (e *LogoTooLargeError) Error() string {
+  return fmt.Sprintf("logo too large for level %c: it hides %d codewords of a block that can only recover %d", e.Level, e.Codewords, e.Budget)
+}
+
+// InvalidCharacterError is returned when a character can't be represented in
+// the encoding mode
+type InvalidCharacterError struct {
+  Char rune
+  // Position is the byte offset of the character in the input
+  Position int
+  Mode EncodingMode
+}
+
+func (e *InvalidCharacterError) Error() string {
+  return fmt.Sprintf("character %q at %d can't be encoded in %s mode", e.Char, e.Position, e.Mode)
+}
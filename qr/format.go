@@ -0,0 +1,97 @@
+package qr
+
+// indicator is the 2 bit error correction level used in the format
+// information, note it isn't in L-M-Q-H order
+func (c CorrectionLevel) indicator() uint32 {
+  switch c {
+  case CorrectionL:
+    return 0b01
+  case CorrectionM:
+    return 0b00
+  case CorrectionQ:
+    return 0b11
+  case CorrectionH:
+    return 0b10
+  }
+  return 0
+}
+
+// bchRemainder is the remainder of data*x^(degree of generator) divided by the
+// generator, all polynomials over GF(2) stored as bits
+func bchRemainder(data uint32, generator uint32) uint32 {
+  degree := bitLength(generator) - 1
+  value := data << degree
+  for bitLength(value) > degree {
+    value ^= generator << (bitLength(value) - bitLength(generator))
+  }
+  return value
+}
+
+func bitLength(value uint32) int {
+  length := 0
+  for value != 0 {
+    value >>= 1
+    length++
+  }
+  return length
+}
+
+// formatBits computes the 15 bit format information: level and mask, their
+// BCH(15,5) code and then XORed with 101010000010010
+func formatBits(correction CorrectionLevel, mask int) uint32 {
+  data := correction.indicator() << 3 | uint32(mask)
+  return (data << 10 | bchRemainder(data, 0b10100110111)) ^ 0b101010000010010
+}
+
+// placeFormat writes both copies of the format information, bit 14 is the
+// most significant. Micro symbols have a single copy
+func placeFormat(m *Matrix, version Version, mask int) {
+  if version.micro {
+    placeMicroFormat(m, version, mask)
+    return
+  }
+  bits := formatBits(version.correction, mask)
+  bit := func(i int) bool {
+    return (bits >> i) & 1 == 1
+  }
+
+  //around the top left finder: up column 8 and then left along row 8
+  for i := 0; i <= 5; i++ {
+    m.setFunction(i, 8, bit(i))
+  }
+  m.setFunction(7, 8, bit(6))
+  m.setFunction(8, 8, bit(7))
+  m.setFunction(8, 7, bit(8))
+  for i := 9; i < 15; i++ {
+    m.setFunction(8, 14-i, bit(i))
+  }
+
+  //row 8 under the top right finder and column 8 next to the bottom left one
+  for i := 0; i < 8; i++ {
+    m.setFunction(8, m.width-1-i, bit(i))
+  }
+  for i := 8; i < 15; i++ {
+    m.setFunction(m.height-15+i, 8, bit(i))
+  }
+}
+
+// versionBits computes the 18 bit version information: the 6 bit version
+// number followed by its BCH(18,6) code
+func versionBits(version Version) uint32 {
+  data := uint32(version.nversion)
+  return data << 12 | bchRemainder(data, 0b1111100100101)
+}
+
+// placeVersion writes the two 6x3 version information blocks, above the
+// bottom left finder and left of the top right one (transposed).
+// Only versions 7 and up have them
+func placeVersion(m *Matrix, version Version) {
+  bits := versionBits(version)
+  for i := 0; i < 18; i++ {
+    dark := (bits >> i) & 1 == 1
+    a := m.width - 11 + i%3
+    b := i / 3
+    m.setFunction(b, a, dark)
+    m.setFunction(a, b, dark)
+  }
+}
@@ -0,0 +1,77 @@
+package qr
+
+import (
+	"fmt"
+	"testing"
+)
+
+// formatTable lists the format information of every level by mask
+var formatTable = map[CorrectionLevel][8]string{
+  CorrectionL: {"111011111000100", "111001011110011", "111110110101010", "111100010011101", "110011000101111", "110001100011000", "110110001000001", "110100101110110"},
+  CorrectionM: {"101010000010010", "101000100100101", "101111001111100", "101101101001011", "100010111111001", "100000011001110", "100111110010111", "100101010100000"},
+  CorrectionQ: {"011010101011111", "011000001101000", "011111100110001", "011101000000110", "010010010110100", "010000110000011", "010111011011010", "010101111101101"},
+  CorrectionH: {"001011010001001", "001001110111110", "001110011100111", "001100111010000", "000011101100010", "000001001010101", "000110100001100", "000100000111011"},
+}
+
+func TestFormatBits(t *testing.T) {
+  for level, masks := range formatTable {
+    for mask, want := range masks {
+      if got := fmt.Sprintf("%015b", formatBits(level, mask)); got != want {
+        t.Errorf("formatBits(%c, %d) = %s, want %s", level, mask, got, want)
+      }
+    }
+  }
+}
+
+// formatCopies reads both copies of the format information, most
+// significant bit first
+func formatCopies(m *Matrix) (string, string) {
+  h, w := m.Height(), m.Width()
+  //around the top left finder: along row 8 skipping the timing column,
+  //then up column 8 skipping the timing row
+  first := [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}, {5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}}
+  //up column 8 from the bottom, then along row 8 to the right edge
+  second := [][2]int{}
+  for row := h-1; row >= h-7; row-- {
+    second = append(second, [2]int{row, 8})
+  }
+  for col := w-8; col < w; col++ {
+    second = append(second, [2]int{8, col})
+  }
+
+  read := func(positions [][2]int) string {
+    bits := ""
+    for _, p := range positions {
+      if m.Get(p[0], p[1]) {
+        bits += "1"
+      } else {
+        bits += "0"
+      }
+    }
+    return bits
+  }
+  return read(first), read(second)
+}
+
+func TestPlaceFormat(t *testing.T) {
+  for _, nversion := range []int{1, 7} {
+    for level, masks := range formatTable {
+      version, err := findVersion(nversion, level, false)
+      if err != nil {
+        t.Fatal(err)
+      }
+      for mask, want := range masks {
+        m := buildFunctionPatterns(version)
+        placeFormat(m, version, mask)
+        first, second := formatCopies(m)
+        if first != want || second != want {
+          t.Errorf("%d-%c mask %d: copies %s and %s, want %s", nversion, level, mask, first, second, want)
+        }
+        //the dark module next to the second copy stays dark
+        if !m.Get(m.Height()-8, 8) {
+          t.Errorf("%d-%c mask %d: the dark module was overwritten", nversion, level, mask)
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,87 @@
+package qr
+
+import (
+	"sync"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+var (
+  kanjiOnce sync.Once
+  kanjiTable map[rune]uint16
+)
+
+// kanjiValues maps every character kanji mode can hold to its double-byte
+// Shift JIS value, in the ranges 0x8140-0x9FFC and 0xE040-0xEBBF. It's
+// built once with a single encoder so classifying characters is a lookup
+func kanjiValues() map[rune]uint16 {
+  kanjiOnce.Do(func() {
+    decoder := japanese.ShiftJIS.NewDecoder()
+    encoder := japanese.ShiftJIS.NewEncoder()
+    kanjiTable = map[rune]uint16{}
+    for _, bounds := range [][2]int{{0x8140, 0x9FFC}, {0xE040, 0xEBBF}} {
+      for value := bounds[0]; value <= bounds[1]; value++ {
+        decoded, err := decoder.Bytes([]byte{byte(value >> 8), byte(value)})
+        if err != nil || len([]rune(string(decoded))) != 1 {
+          continue
+        }
+        //some characters have two codes, keep the one the encoder picks
+        char := []rune(string(decoded))[0]
+        sjis, err := encoder.String(string(char))
+        if err != nil || len(sjis) != 2 || int(sjis[0]) << 8 | int(sjis[1]) != value {
+          continue
+        }
+        kanjiTable[char] = uint16(value)
+      }
+    }
+  })
+  return kanjiTable
+}
+
+// shiftJISKanji converts each character to its double-byte Shift JIS value.
+// It fails if any of them can't be represented in the ranges kanji mode
+// supports (0x8140-0x9FFC and 0xE040-0xEBBF), which happens for many
+// unicode.Han runes and for anything single-byte
+func shiftJISKanji(input string) ([]uint16, error) {
+  table := kanjiValues()
+  result := []uint16{}
+  for i, char := range input {
+    value, ok := table[char]
+    if !ok {
+      return nil, &InvalidCharacterError{Char: char, Position: i, Mode: Kanji}
+    }
+    result = append(result, value)
+  }
+  return result, nil
+}
+
+func isKanji(input string) bool {
+  _, err := shiftJISKanji(input)
+  return len(input) > 0 && err == nil
+}
+
+// isKanjiChar tells if kanji mode can hold the character
+func isKanjiChar(char rune) bool {
+  _, ok := kanjiValues()[char]
+  return ok
+}
+
+// encodeKanji subtracts 0x8140 or 0xC140 from each Shift JIS value, then
+// packs (high byte * 0xC0 + low byte) in 13 bits
+func encodeKanji(input string) (*BitBuffer, error) {
+  sjis, err := shiftJISKanji(input)
+  if err != nil {
+    return nil, err
+  }
+  buffer := &BitBuffer{}
+  for _, value := range sjis {
+    if value <= 0x9FFC {
+      value -= 0x8140
+    } else {
+      value -= 0xC140
+    }
+    buffer.AppendBits(uint32(value >> 8) * 0xC0 + uint32(value & 0xFF), 13)
+  }
+
+  return buffer, nil
+}
@@ -0,0 +1,189 @@
+package qr
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+// LogoArea is a square of modules reserved for a logo, in symbol
+// coordinates (without quiet zone)
+type LogoArea struct {
+  Row int
+  Col int
+  Size int
+}
+
+func (a LogoArea) contains(row, col int) bool {
+  return row >= a.Row && row < a.Row+a.Size && col >= a.Col && col < a.Col+a.Size
+}
+
+// withLogoBudget runs encode and, when the logo hides more than the level can
+// recover, tries again at level H. When the version is picked automatically
+// and the logo still doesn't fit, it steps up to the next versions that can
+// hold it, keeping the level when they can. That skips the versions with an
+// alignment pattern in the center
+func withLogoBudget(options Options, encode func(Options) (*Symbol, error)) (*Symbol, error) {
+  symbol, err := withLevelH(options, encode)
+  if options.Version != 0 || !logoDoesntFit(err) {
+    return symbol, err
+  }
+
+  //smaller versions than the automatic one fail quickly with too much data
+  options.Micro = false
+  for nversion := 1; nversion <= 40; nversion++ {
+    options.Version = nversion
+    if symbol, stepErr := withLevelH(options, encode); stepErr == nil {
+      return symbol, nil
+    }
+  }
+  return nil, err
+}
+
+// withLevelH runs encode and tries again at level H when the logo hides too
+// many codewords. The first error is kept when level H fails too
+func withLevelH(options Options, encode func(Options) (*Symbol, error)) (*Symbol, error) {
+  symbol, err := encode(options)
+  var tooLarge *LogoTooLargeError
+  if errors.As(err, &tooLarge) && options.Level != CorrectionH {
+    options.Level = CorrectionH
+    if symbol, raisedErr := encode(options); raisedErr == nil {
+      return symbol, nil
+    }
+  }
+  return symbol, err
+}
+
+// logoDoesntFit tells if the error comes from the logo area of a symbol that
+// a larger version could fix
+func logoDoesntFit(err error) bool {
+  var tooLarge *LogoTooLargeError
+  return errors.Is(err, ErrLogoCoversFunction) || errors.Is(err, ErrSymbolTooLargeForLogo) || errors.As(err, &tooLarge)
+}
+
+// logoArea centers a square of the given fraction of the side, with the
+// same parity as the side so the margins match
+func logoArea(symbolSize int, fraction float64) LogoArea {
+  size := int(fraction * float64(symbolSize) + 0.5)
+  if (symbolSize - size) % 2 != 0 {
+    size++
+  }
+  offset := (symbolSize - size) / 2
+  return LogoArea{Row: offset, Col: offset, Size: size}
+}
+
+// misdecodeProtection is the number of error correction codewords per block
+// that small symbols keep to avoid misreads instead of correcting errors
+func misdecodeProtection(version Version) int {
+  switch {
+  case version.nversion == 1 && version.correction == CorrectionL:
+    return 3
+  case version.nversion == 1 && version.correction == CorrectionM:
+    return 2
+  case version.nversion == 1:
+    return 1
+  case version.nversion == 2 && version.correction == CorrectionL:
+    return 2
+  case version.nversion == 3 && version.correction == CorrectionL:
+    return 1
+  }
+  return 0
+}
+
+// codewordBlocks returns the block of every codeword in the interleaved
+// message, following the same order as interleave
+func codewordBlocks(version Version) []int {
+  numBlocks := version.blocksGroup1 + version.blocksGroup2
+  blockWords := func(block int) int {
+    if block < version.blocksGroup1 {
+      return version.wordsBlockGroup1
+    }
+    return version.wordsBlockGroup2
+  }
+
+  result := []int{}
+  maxData := max(version.wordsBlockGroup1, version.wordsBlockGroup2)
+  for i := 0; i < maxData; i++ {
+    for block := 0; block < numBlocks; block++ {
+      if i < blockWords(block) {
+        result = append(result, block)
+      }
+    }
+  }
+  for i := 0; i < version.ecWordsBlock; i++ {
+    for block := 0; block < numBlocks; block++ {
+      result = append(result, block)
+    }
+  }
+  return result
+}
+
+// reserveLogo finds the logo area on the placed matrix and checks it can be
+// hidden: it can't touch function patterns, and the codewords it hides in
+// each block must be within what the block can correct (half its error
+// correction codewords, minus the misdecode protection ones).
+// A smaller logo can't help when the center itself is a function module,
+// the alignment pattern of versions 7-13, 21-27 and 35-40
+func reserveLogo(m *Matrix, version Version, fraction float64) (LogoArea, error) {
+  area := logoArea(m.width, fraction)
+  if m.function[m.height/2][m.width/2] {
+    return LogoArea{}, ErrSymbolTooLargeForLogo
+  }
+  for row := area.Row; row < area.Row+area.Size; row++ {
+    for col := area.Col; col < area.Col+area.Size; col++ {
+      if m.function[row][col] {
+        return LogoArea{}, ErrLogoCoversFunction
+      }
+    }
+  }
+
+  blocks := codewordBlocks(version)
+  hidden := map[int]bool{}
+  bit := 0
+  forEachDataModule(m, func(row, col int) {
+    codeword := bit / 8
+    //remainder bits are past the last codeword and carry nothing
+    if area.contains(row, col) && codeword < len(blocks) {
+      hidden[codeword] = true
+    }
+    bit++
+  })
+
+  perBlock := make([]int, version.blocksGroup1 + version.blocksGroup2)
+  worst := 0
+  for codeword := range hidden {
+    block := blocks[codeword]
+    perBlock[block]++
+    worst = max(worst, perBlock[block])
+  }
+
+  budget := version.ecWordsBlock/2 - misdecodeProtection(version)
+  if worst > budget {
+    return LogoArea{}, &LogoTooLargeError{Level: version.correction, Codewords: worst, Budget: budget}
+  }
+  return area, nil
+}
+
+// drawLogo scales the logo into the area of img (in pixels) with nearest
+// neighbour sampling, over a background fill so the modules under it don't
+// show through transparent parts
+func drawLogo(img *image.NRGBA, area image.Rectangle, logo image.Image, background color.Color) {
+  bounds := logo.Bounds()
+  for y := area.Min.Y; y < area.Max.Y; y++ {
+    for x := area.Min.X; x < area.Max.X; x++ {
+      sx := bounds.Min.X + (x - area.Min.X) * bounds.Dx() / area.Dx()
+      sy := bounds.Min.Y + (y - area.Min.Y) * bounds.Dy() / area.Dy()
+      img.Set(x, y, over(logo.At(sx, sy), background))
+    }
+  }
+}
+
+// over composites top over bottom
+func over(top, bottom color.Color) color.Color {
+  tr, tg, tb, ta := top.RGBA()
+  br, bg, bb, ba := bottom.RGBA()
+  blend := func(t, b uint32) uint16 {
+    return uint16(t + b * (0xFFFF - ta) / 0xFFFF)
+  }
+  return color.RGBA64{R: blend(tr, br), G: blend(tg, bg), B: blend(tb, bb), A: blend(ta, ba)}
+}
@@ -0,0 +1,165 @@
+package qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMisdecodeProtection(t *testing.T) {
+  tests := []struct {
+    nversion int
+    level CorrectionLevel
+    want int
+  }{
+    {1, CorrectionL, 3},
+    {1, CorrectionM, 2},
+    {1, CorrectionQ, 1},
+    {1, CorrectionH, 1},
+    {2, CorrectionL, 2},
+    {2, CorrectionM, 0},
+    {3, CorrectionL, 1},
+    {3, CorrectionQ, 0},
+    {4, CorrectionL, 0},
+    {6, CorrectionH, 0},
+  }
+  for _, test := range tests {
+    version, err := findVersion(test.nversion, test.level, false)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if got := misdecodeProtection(version); got != test.want {
+      t.Errorf("%d-%c: got %d, want %d", test.nversion, test.level, got, test.want)
+    }
+  }
+}
+
+// hiddenPerBlock counts the codewords with a module in the area for each
+// block, placing every codeword alone to find its modules
+func hiddenPerBlock(version Version, area LogoArea) []int {
+  //every codeword holds its block number
+  blocks := labeledBlocks(version)
+  for b := range blocks {
+    for i := range blocks[b].data {
+      blocks[b].data[i] = byte(b)
+    }
+    for i := range blocks[b].ec {
+      blocks[b].ec[i] = byte(b)
+    }
+  }
+  message := interleave(blocks, version)
+  labels := message.Bytes()[:message.Len()/8]
+
+  perBlock := make([]int, len(blocks))
+  for codeword := range labels {
+    message := &BitBuffer{}
+    message.AppendBytes(make([]byte, codeword))
+    message.AppendBits(0xFF, 8)
+    m := buildFunctionPatterns(version)
+    placeData(m, message)
+    for row := area.Row; row < area.Row+area.Size; row++ {
+      for col := area.Col; col < area.Col+area.Size; col++ {
+        if m.modules[row][col] && !m.function[row][col] {
+          perBlock[labels[codeword]]++
+          row, col = area.Row+area.Size, area.Col+area.Size
+        }
+      }
+    }
+  }
+  return perBlock
+}
+
+func TestReserveLogoBudget(t *testing.T) {
+  for _, nversion := range []int{2, 3, 4, 5, 6} {
+    for _, level := range []CorrectionLevel{CorrectionL, CorrectionM, CorrectionQ, CorrectionH} {
+      version, err := findVersion(nversion, level, false)
+      if err != nil {
+        t.Fatal(err)
+      }
+      m := buildFunctionPatterns(version)
+      for _, fraction := range []float64{0.1, 0.2, 0.25, 0.3} {
+        area := logoArea(m.width, fraction)
+        worst := 0
+        for _, hidden := range hiddenPerBlock(version, area) {
+          worst = max(worst, hidden)
+        }
+        budget := version.ecWordsBlock/2 - misdecodeProtection(version)
+
+        got, err := reserveLogo(m, version, fraction)
+        var tooLarge *LogoTooLargeError
+        switch {
+        case errors.Is(err, ErrLogoCoversFunction):
+          //the area must reach a function module
+          covers := false
+          for row := area.Row; row < area.Row+area.Size; row++ {
+            for col := area.Col; col < area.Col+area.Size; col++ {
+              covers = covers || m.function[row][col]
+            }
+          }
+          if !covers {
+            t.Errorf("%d-%c %.2f: %v for %+v", nversion, level, fraction, err, area)
+          }
+        case worst > budget:
+          if !errors.As(err, &tooLarge) || tooLarge.Codewords != worst || tooLarge.Budget != budget || tooLarge.Level != level {
+            t.Errorf("%d-%c %.2f: got %v, want %d codewords over a budget of %d", nversion, level, fraction, err, worst, budget)
+          }
+        case err != nil || got != area:
+          t.Errorf("%d-%c %.2f: got %+v, %v, want %+v", nversion, level, fraction, got, err, area)
+        }
+      }
+    }
+  }
+}
+
+func TestReserveLogoCenter(t *testing.T) {
+  version, err := findVersion(7, CorrectionH, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  m := buildFunctionPatterns(version)
+  if _, err := reserveLogo(m, version, 0.05); !errors.Is(err, ErrSymbolTooLargeForLogo) {
+    t.Errorf("got %v, want ErrSymbolTooLargeForLogo", err)
+  }
+}
+
+func TestEncodeLogo(t *testing.T) {
+  tests := []struct {
+    name string
+    data string
+    level CorrectionLevel
+    version int
+    size float64
+    wantVersion int
+    wantLevel CorrectionLevel
+    wantErr error
+  }{
+    //the area of version 1 reaches the format information at (8, 8)
+    {"next version", "hi", CorrectionM, 0, 0.2, 2, CorrectionM, nil},
+    {"forced version", "hi", CorrectionM, 1, 0.2, 0, 0, ErrLogoCoversFunction},
+    {"fits", "hello", CorrectionM, 0, 0.1, 1, CorrectionM, nil},
+    {"raised to H", "HELLO WORLD", CorrectionL, 3, 0.3, 3, CorrectionH, nil},
+    //8-H has an alignment pattern in the center, 14-H doesn't
+    {"past the center alignment", strings.Repeat("A", 100), CorrectionH, 0, 0.2, 14, CorrectionH, nil},
+    {"center alignment", "hi", CorrectionM, 7, 0.1, 0, 0, ErrSymbolTooLargeForLogo},
+  }
+  for _, test := range tests {
+    options := DefaultOptions()
+    options.Level = test.level
+    options.Version = test.version
+    options.LogoSize = test.size
+    symbol, err := Encode(test.data, options)
+    if test.wantErr != nil {
+      if !errors.Is(err, test.wantErr) {
+        t.Errorf("%s: got %v, want %v", test.name, err, test.wantErr)
+      }
+      continue
+    }
+    if err != nil {
+      t.Errorf("%s: %v", test.name, err)
+      continue
+    }
+    if symbol.Version != test.wantVersion || symbol.Level != test.wantLevel || symbol.Logo.Size == 0 {
+      t.Errorf("%s: got %d-%c with logo %+v, want %d-%c", test.name, symbol.Version, symbol.Level, symbol.Logo, test.wantVersion, test.wantLevel)
+    }
+  }
+}
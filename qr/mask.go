@@ -0,0 +1,220 @@
+package qr
+
+// AutoMask lets selectMask pick the pattern with the lowest penalty
+const AutoMask = -1
+
+// MaskScore is the penalty of a mask pattern, per rule and in total. The
+// lowest Total wins
+type MaskScore struct {
+  Mask int
+  // Penalties are the four penalty rules of regular symbols, Micro symbols
+  // leave them at 0
+  Penalties [4]int
+  // MicroScore is how Micro symbols are evaluated instead: the dark modules
+  // on their right and bottom edges, the highest score wins
+  MicroScore int
+  // Total is the sum of the Penalties, or the negated MicroScore for Micro
+  // symbols so that the lowest still wins
+  Total int
+}
+
+// microMasks are the patterns of the 4 Micro QR masks
+var microMasks = [4]int{1, 4, 6, 7}
+
+// rectMask is the pattern of the only rMQR mask
+const rectMask = 4
+
+// maskCondition tells if the module at row, col is inverted by the mask
+func maskCondition(mask int, row, col int) bool {
+  switch mask {
+  case 0:
+    return (row+col)%2 == 0
+  case 1:
+    return row%2 == 0
+  case 2:
+    return col%3 == 0
+  case 3:
+    return (row+col)%3 == 0
+  case 4:
+    return (row/2 + col/3)%2 == 0
+  case 5:
+    return (row*col)%2 + (row*col)%3 == 0
+  case 6:
+    return ((row*col)%2 + (row*col)%3)%2 == 0
+  case 7:
+    return ((row+col)%2 + (row*col)%3)%2 == 0
+  }
+  return false
+}
+
+// applyMask inverts the data modules selected by the mask, function modules
+// are left untouched. Applying the same mask twice undoes it.
+// Micro masks 0-3 use the patterns in microMasks
+func applyMask(m *Matrix, mask int) {
+  pattern := mask
+  if m.micro {
+    pattern = microMasks[mask]
+  }
+  if m.rect {
+    pattern = rectMask
+  }
+  for row := 0; row < m.height; row++ {
+    for col := 0; col < m.width; col++ {
+      if !m.function[row][col] && maskCondition(pattern, row, col) {
+        m.modules[row][col] = !m.modules[row][col]
+      }
+    }
+  }
+}
+
+func (m *Matrix) clone() *Matrix {
+  c := newMatrix(m.width, m.height)
+  c.micro = m.micro
+  c.rect = m.rect
+  for i := range m.modules {
+    copy(c.modules[i], m.modules[i])
+    copy(c.function[i], m.function[i])
+  }
+  return c
+}
+
+// evaluateMasks scores the eight mask patterns on the placed matrix, with
+// the format information for each of them in place.
+// Micro symbols have four masks scored by MicroScore
+func evaluateMasks(m *Matrix, version Version) []MaskScore {
+  scores := make([]MaskScore, 8)
+  if version.micro {
+    scores = scores[:4]
+  }
+  for mask := range scores {
+    masked := m.clone()
+    applyMask(masked, mask)
+    placeFormat(masked, version, mask)
+    if version.micro {
+      score := microMaskScore(masked)
+      scores[mask] = MaskScore{Mask: mask, MicroScore: score, Total: -score}
+      continue
+    }
+    penalties := penalty(masked)
+    scores[mask] = MaskScore{Mask: mask, Penalties: penalties, Total: penalties[0] + penalties[1] + penalties[2] + penalties[3]}
+  }
+  return scores
+}
+
+// selectMask applies the mask with the lowest penalty (the first one on
+// ties) to the matrix, or the forced one when it isn't AutoMask, and writes
+// the matching format information.
+// The scores of all the masks are returned to audit the choice
+func selectMask(m *Matrix, version Version, forced int) (int, []MaskScore) {
+  if version.rmqr {
+    //a single mask, the format information is already in place
+    applyMask(m, 0)
+    return 0, nil
+  }
+  scores := evaluateMasks(m, version)
+
+  chosen := forced
+  if forced == AutoMask {
+    chosen = lowestMask(scores)
+  }
+  applyMask(m, chosen)
+  placeFormat(m, version, chosen)
+
+  return chosen, scores
+}
+
+// lowestMask is the mask with the lowest total, the first one on ties
+func lowestMask(scores []MaskScore) int {
+  chosen := 0
+  for i, score := range scores {
+    if score.Total < scores[chosen].Total {
+      chosen = i
+    }
+  }
+  return scores[chosen].Mask
+}
+
+// penalty computes the four penalty rules:
+// 1. runs of 5 or more modules of the same color in a row or column
+// 2. 2x2 blocks of the same color
+// 3. finder-like patterns (1:1:3:1:1 with 4 light modules on one side)
+// 4. deviation of the proportion of dark modules from 50%
+func penalty(m *Matrix) [4]int {
+  var result [4]int
+
+  //rows and columns are read the same way, get(i, j) transposes for columns
+  lines := []struct {
+    count, length int
+    get func(i, j int) bool
+  }{
+    {m.height, m.width, func(i, j int) bool { return m.modules[i][j] }},
+    {m.width, m.height, func(i, j int) bool { return m.modules[j][i] }},
+  }
+  finderLike := [][]bool{
+    {true, false, true, true, true, false, true, false, false, false, false},
+    {false, false, false, false, true, false, true, true, true, false, true},
+  }
+
+  for _, line := range lines {
+    get := line.get
+    for i := 0; i < line.count; i++ {
+      //rule 1
+      run := 1
+      for j := 1; j < line.length; j++ {
+        if get(i, j) == get(i, j-1) {
+          run++
+          continue
+        }
+        if run >= 5 {
+          result[0] += 3 + run - 5
+        }
+        run = 1
+      }
+      if run >= 5 {
+        result[0] += 3 + run - 5
+      }
+
+      //rule 3
+      for j := 0; j+11 <= line.length; j++ {
+        for _, pattern := range finderLike {
+          matches := true
+          for k, dark := range pattern {
+            if get(i, j+k) != dark {
+              matches = false
+              break
+            }
+          }
+          if matches {
+            result[2] += 40
+          }
+        }
+      }
+    }
+  }
+
+  //rule 2
+  for row := 0; row < m.height-1; row++ {
+    for col := 0; col < m.width-1; col++ {
+      color := m.modules[row][col]
+      if m.modules[row][col+1] == color && m.modules[row+1][col] == color && m.modules[row+1][col+1] == color {
+        result[1] += 3
+      }
+    }
+  }
+
+  //rule 4
+  dark := 0
+  for row := range m.modules {
+    for _, module := range m.modules[row] {
+      if module {
+        dark++
+      }
+    }
+  }
+  percent := dark * 100 / (m.width * m.height)
+  prev := percent - percent%5
+  next := prev + 5
+  result[3] = min(abs(prev-50), abs(next-50)) / 5 * 10
+
+  return result
+}
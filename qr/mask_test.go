@@ -0,0 +1,133 @@
+package qr
+
+import (
+	"strings"
+	"testing"
+)
+
+// matrixFrom builds a matrix from rows of # (dark) and . (light)
+func matrixFrom(rows ...string) *Matrix {
+  m := newMatrix(len(rows[0]), len(rows))
+  for row, line := range rows {
+    for col, char := range line {
+      m.modules[row][col] = char == '#'
+    }
+  }
+  return m
+}
+
+// darkMatrix is a 10x10 matrix with its first count modules dark
+func darkMatrix(count int) *Matrix {
+  m := newMatrix(10, 10)
+  for i := 0; i < count; i++ {
+    m.modules[i/10][i%10] = true
+  }
+  return m
+}
+
+func TestPenaltyRules(t *testing.T) {
+  tests := []struct {
+    name string
+    matrix *Matrix
+    rule int
+    want int
+  }{
+    {"run of 4", matrixFrom("####.#.#"), 0, 0},
+    {"run of 5", matrixFrom("#####.#."), 0, 3},
+    {"run of 7", matrixFrom(".#######"), 0, 5},
+    {"light runs count too", matrixFrom("......#....."), 0, 7},
+    {"column run of 5", matrixFrom("#", "#", "#", "#", "#", "."), 0, 3},
+    {"checkerboard", matrixFrom("#.#.", ".#.#", "#.#.", ".#.#"), 0, 0},
+
+    {"one 2x2 block", matrixFrom("##.", "##.", "..#"), 1, 3},
+    {"overlapping blocks", matrixFrom("###", "###", "###"), 1, 12},
+    {"light blocks", matrixFrom("..#", "..#", "#.#"), 1, 3},
+    {"no blocks", matrixFrom("#.#.", ".#.#", "#.#."), 1, 0},
+
+    {"finder then light", matrixFrom("#.###.#...."), 2, 40},
+    {"light then finder", matrixFrom("....#.###.#"), 2, 40},
+    {"light on both sides", matrixFrom("....#.###.#...."), 2, 80},
+    {"finder without light", matrixFrom("#.###.#"), 2, 0},
+    {"column finder", matrixFrom(strings.Split("#.###.#....", "")...), 2, 40},
+
+    {"50% dark", darkMatrix(50), 3, 0},
+    {"43% dark", darkMatrix(43), 3, 10},
+    {"57% dark", darkMatrix(57), 3, 10},
+    {"33% dark", darkMatrix(33), 3, 30},
+    {"all dark", darkMatrix(100), 3, 100},
+  }
+
+  for _, test := range tests {
+    if got := penalty(test.matrix)[test.rule]; got != test.want {
+      t.Errorf("%s: rule %d = %d, want %d", test.name, test.rule+1, got, test.want)
+    }
+  }
+}
+
+// placedMatrix is the 1-M "01234567" matrix before masking
+func placedMatrix(t *testing.T) (*Matrix, Version) {
+  version, err := findVersion(1, CorrectionM, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  encoded, err := encode([]Segment{{mode: Numeric, data: "01234567"}}, version, CorrectionM, StructuredAppend{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  m := buildFunctionPatterns(version)
+  placeData(m, interleave(errorCorrection(encoded, version), version))
+  return m, version
+}
+
+func TestSelectMaskForced(t *testing.T) {
+  m, version := placedMatrix(t)
+  mask, scores := selectMask(m, version, 5)
+  if mask != 5 {
+    t.Errorf("got mask %d, want the forced 5", mask)
+  }
+  if len(scores) != 8 {
+    t.Errorf("got %d scores, want 8", len(scores))
+  }
+  if first, _ := formatCopies(m); first != formatTable[CorrectionM][5] {
+    t.Errorf("format %s, want the one of mask 5 %s", first, formatTable[CorrectionM][5])
+  }
+}
+
+func TestSelectMaskLowest(t *testing.T) {
+  m, version := placedMatrix(t)
+  unmasked := m.clone()
+  mask, scores := selectMask(m, version, AutoMask)
+
+  for i, score := range scores {
+    masked := unmasked.clone()
+    applyMask(masked, i)
+    placeFormat(masked, version, i)
+    penalties := penalty(masked)
+    if score.Mask != i || score.Penalties != penalties || score.Total != penalties[0]+penalties[1]+penalties[2]+penalties[3] {
+      t.Errorf("mask %d: score %+v, penalties %v", i, score, penalties)
+    }
+  }
+  if want := lowestMask(scores); mask != want {
+    t.Errorf("got mask %d, want %d with scores %+v", mask, want, scores)
+  }
+}
+
+func TestLowestMask(t *testing.T) {
+  tests := []struct {
+    totals []int
+    want int
+  }{
+    {[]int{500, 420, 610, 430, 420, 700, 800, 421}, 1},
+    {[]int{300, 300, 300, 300, 300, 300, 300, 300}, 0},
+    {[]int{900, 800, 700, 600, 500, 400, 300, 299}, 7},
+  }
+  for _, test := range tests {
+    scores := make([]MaskScore, len(test.totals))
+    for i, total := range test.totals {
+      scores[i] = MaskScore{Mask: i, Total: total}
+    }
+    if got := lowestMask(scores); got != test.want {
+      t.Errorf("lowestMask(%v) = %d, want %d", test.totals, got, test.want)
+    }
+  }
+}
@@ -0,0 +1,243 @@
+package qr
+
+// Matrix is the grid of modules of a symbol, true is a dark module.
+// function marks the modules that belong to function patterns or reserved
+// areas, the data placement and masking never touch those
+type Matrix struct {
+  width int
+  height int
+  modules [][]bool
+  function [][]bool
+  // micro matrices have the timing patterns on the edges and 4 masks
+  micro bool
+  // rect matrices (rMQR) have timing patterns on every edge and one mask
+  rect bool
+}
+
+func newMatrix(width, height int) *Matrix {
+  modules := make([][]bool, height)
+  function := make([][]bool, height)
+  for i := range modules {
+    modules[i] = make([]bool, width)
+    function[i] = make([]bool, width)
+  }
+  return &Matrix{width: width, height: height, modules: modules, function: function}
+}
+
+func (m *Matrix) Width() int {
+  return m.width
+}
+
+func (m *Matrix) Height() int {
+  return m.height
+}
+
+// Get tells if the module at row, col is dark
+func (m *Matrix) Get(row, col int) bool {
+  return m.modules[row][col]
+}
+
+// IsFunction tells if the module at row, col is part of a function pattern
+func (m *Matrix) IsFunction(row, col int) bool {
+  return m.function[row][col]
+}
+
+// setFunction sets a module and marks it as a function module
+func (m *Matrix) setFunction(row, col int, dark bool) {
+  m.modules[row][col] = dark
+  m.function[row][col] = true
+}
+
+func symbolSize(version Version) int {
+  if version.micro {
+    return 9 + 2*version.nversion
+  }
+  return 17 + 4*version.nversion
+}
+
+// buildFunctionPatterns creates the matrix for the version with all the
+// function patterns and the version information placed, and the format
+// areas reserved
+func buildFunctionPatterns(version Version) *Matrix {
+  if version.rmqr {
+    return buildRectFunctionPatterns(version)
+  }
+  if version.micro {
+    return buildMicroFunctionPatterns(version)
+  }
+  size := symbolSize(version)
+  m := newMatrix(size, size)
+
+  //timing patterns first, finders and alignments overwrite their ends
+  for i := 0; i < size; i++ {
+    m.setFunction(6, i, i%2 == 0)
+    m.setFunction(i, 6, i%2 == 0)
+  }
+
+  m.placeFinder(0, 0)
+  m.placeFinder(0, size-7)
+  m.placeFinder(size-7, 0)
+
+  centers := alignmentCenters(version)
+  last := len(centers)-1
+  for i, row := range centers {
+    for j, col := range centers {
+      //the ones in the finder corners are skipped
+      if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+        continue
+      }
+      m.placeAlignment(row, col)
+    }
+  }
+
+  m.reserveFormat()
+  if version.nversion >= 7 {
+    placeVersion(m, version)
+  }
+
+  //dark module, always next to the bottom left separator
+  m.setFunction(4*version.nversion+9, 8, true)
+
+  return m
+}
+
+// placeFinder draws a 7x7 finder with its top left corner at row, col,
+// along with the light separator around it
+func (m *Matrix) placeFinder(row, col int) {
+  for r := -1; r <= 7; r++ {
+    for c := -1; c <= 7; c++ {
+      y, x := row+r, col+c
+      if y < 0 || y >= m.height || x < 0 || x >= m.width {
+        continue
+      }
+      ring := max(abs(r-3), abs(c-3))
+      //dark outer ring and 3x3 center, light in between and in the separator
+      m.setFunction(y, x, ring != 2 && ring != 4)
+    }
+  }
+}
+
+// placeAlignment draws a 5x5 alignment pattern centered at row, col
+func (m *Matrix) placeAlignment(row, col int) {
+  for r := -2; r <= 2; r++ {
+    for c := -2; c <= 2; c++ {
+      m.setFunction(row+r, col+c, max(abs(r), abs(c)) != 1)
+    }
+  }
+}
+
+// reserveFormat marks the two copies of the format information as function
+// modules, the values are written once the mask is known
+func (m *Matrix) reserveFormat() {
+  for i := 0; i <= 8; i++ {
+    //around the top left finder, skipping the timing patterns
+    if i != 6 {
+      m.setFunction(8, i, false)
+      m.setFunction(i, 8, false)
+    }
+  }
+  for i := 0; i < 8; i++ {
+    //bottom left and top right
+    m.setFunction(m.height-1-i, 8, false)
+    m.setFunction(8, m.width-1-i, false)
+  }
+}
+
+func abs(x int) int {
+  if x < 0 {
+    return -x
+  }
+  return x
+}
+
+// alignmentCenters returns the row/column coordinates of the alignment
+// pattern centers, every combination of them is a center except the ones
+// that overlap with the finders
+func alignmentCenters(version Version) []int {
+  return alignmentTable()[version.nversion-1]
+}
+
+func alignmentTable() [][]int {
+  return [][]int{
+    {},
+    {6, 18},
+    {6, 22},
+    {6, 26},
+    {6, 30},
+    {6, 34},
+    {6, 22, 38},
+    {6, 24, 42},
+    {6, 26, 46},
+    {6, 28, 50},
+    {6, 30, 54},
+    {6, 32, 58},
+    {6, 34, 62},
+    {6, 26, 46, 66},
+    {6, 26, 48, 70},
+    {6, 26, 50, 74},
+    {6, 30, 54, 78},
+    {6, 30, 56, 82},
+    {6, 30, 58, 86},
+    {6, 34, 62, 90},
+    {6, 28, 50, 72, 94},
+    {6, 26, 50, 74, 98},
+    {6, 30, 54, 78, 102},
+    {6, 28, 54, 80, 106},
+    {6, 32, 58, 84, 110},
+    {6, 30, 58, 86, 114},
+    {6, 34, 62, 90, 118},
+    {6, 26, 50, 74, 98, 122},
+    {6, 30, 54, 78, 102, 126},
+    {6, 26, 52, 78, 104, 130},
+    {6, 30, 56, 82, 108, 134},
+    {6, 34, 60, 86, 112, 138},
+    {6, 30, 58, 86, 114, 142},
+    {6, 34, 62, 90, 118, 146},
+    {6, 30, 54, 78, 102, 126, 150},
+    {6, 24, 50, 76, 102, 128, 154},
+    {6, 28, 54, 80, 106, 132, 158},
+    {6, 32, 58, 84, 110, 136, 162},
+    {6, 26, 54, 82, 110, 138, 166},
+    {6, 30, 58, 86, 114, 142, 170},
+  }
+}
+
+// placeData fills the non function modules with the message bits in the
+// zig-zag order: pairs of columns from right to left, alternating upwards
+// and downwards, skipping the vertical timing column
+func placeData(m *Matrix, message *BitBuffer) {
+  reader := NewBitReader(message)
+  forEachDataModule(m, func(row, col int) {
+    m.modules[row][col] = reader.ReadBit()
+  })
+}
+
+// forEachDataModule calls visit for every non function module in placement
+// order, so the nth call is for the nth bit of the message.
+// Micro symbols have the timing column on the edge, nothing is skipped.
+// rMQR symbols have it on both edges, the pairs start left of the right one
+func forEachDataModule(m *Matrix, visit func(row, col int)) {
+  upward := true
+  start := m.width-1
+  if m.rect {
+    start = m.width-2
+  }
+  for right := start; right >= 1; right -= 2 {
+    if right == 6 && !m.micro {
+      right = 5
+    }
+    for vert := 0; vert < m.height; vert++ {
+      row := vert
+      if upward {
+        row = m.height-1-vert
+      }
+      for j := 0; j < 2; j++ {
+        col := right - j
+        if !m.function[row][col] {
+          visit(row, col)
+        }
+      }
+    }
+    upward = !upward
+  }
+}
@@ -0,0 +1,70 @@
+package qr
+
+import (
+	"strings"
+	"testing"
+)
+
+// the ISO 1-M "01234567" symbol with mask 2, # is a dark module
+var golden1M = []string{
+  "#######..#.##.#######",
+  "#.....#..####.#.....#",
+  "#.###.#.#.....#.###.#",
+  "#.###.#.##....#.###.#",
+  "#.###.#.#.###.#.###.#",
+  "#.....#.#...#.#.....#",
+  "#######.#.#.#.#######",
+  "........#..##........",
+  "#.#####..#..#.#####..",
+  "...#.#.##.#.#..#.##..",
+  "..#...##.#.#.#..#####",
+  "....#....#.....####..",
+  "...######..#.#..#....",
+  "........#.#####..##..",
+  "#######..##.#.##.....",
+  "#.....#.#.#####...#.#",
+  "#.###.#.#...#..#.##..",
+  "#.###.#.##..#..#.....",
+  "#.###.#.#.##.#..#.#..",
+  "#.....#........##.##.",
+  "#######.####.#..#.#..",
+}
+
+// gridString draws the modules like golden1M, one row per line
+func gridString(modules [][]bool) string {
+  var sb strings.Builder
+  for _, row := range modules {
+    for _, dark := range row {
+      if dark {
+        sb.WriteByte('#')
+      } else {
+        sb.WriteByte('.')
+      }
+    }
+    sb.WriteByte('\n')
+  }
+  return sb.String()
+}
+
+func TestPlaceDataGolden(t *testing.T) {
+  version, err := findVersion(1, CorrectionM, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  encoded, err := encode([]Segment{{mode: Numeric, data: "01234567"}}, version, CorrectionM, StructuredAppend{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  message := interleave(errorCorrection(encoded, version), version)
+
+  matrix := buildFunctionPatterns(version)
+  placeData(matrix, message)
+  applyMask(matrix, 2)
+  placeFormat(matrix, version, 2)
+
+  got := gridString(matrix.modules)
+  want := strings.Join(golden1M, "\n") + "\n"
+  if got != want {
+    t.Errorf("modules:\n%s\nwant:\n%s", got, want)
+  }
+}
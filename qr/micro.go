@@ -0,0 +1,96 @@
+package qr
+
+// listMicroVersions is the version table of Micro QR symbols, they have a
+// single block. Modes a version lacks have capacity 0
+func listMicroVersions() []Version {
+  return []Version{
+    {nversion: 1, micro: true, correction: CorrectionL, capNum: 5, capAlpha: 0, capByte: 0, capKanji: 0, totalWords: 3, blocksGroup1: 1, wordsBlockGroup1: 3, ecWordsBlock: 2},
+
+    {nversion: 2, micro: true, correction: CorrectionL, capNum: 10, capAlpha: 6, capByte: 0, capKanji: 0, totalWords: 5, blocksGroup1: 1, wordsBlockGroup1: 5, ecWordsBlock: 5},
+    {nversion: 2, micro: true, correction: CorrectionM, capNum: 8, capAlpha: 5, capByte: 0, capKanji: 0, totalWords: 4, blocksGroup1: 1, wordsBlockGroup1: 4, ecWordsBlock: 6},
+
+    {nversion: 3, micro: true, correction: CorrectionL, capNum: 23, capAlpha: 14, capByte: 9, capKanji: 6, totalWords: 11, blocksGroup1: 1, wordsBlockGroup1: 11, ecWordsBlock: 6},
+    {nversion: 3, micro: true, correction: CorrectionM, capNum: 18, capAlpha: 11, capByte: 7, capKanji: 4, totalWords: 9, blocksGroup1: 1, wordsBlockGroup1: 9, ecWordsBlock: 8},
+
+    {nversion: 4, micro: true, correction: CorrectionL, capNum: 35, capAlpha: 21, capByte: 15, capKanji: 9, totalWords: 16, blocksGroup1: 1, wordsBlockGroup1: 16, ecWordsBlock: 8},
+    {nversion: 4, micro: true, correction: CorrectionM, capNum: 30, capAlpha: 18, capByte: 13, capKanji: 8, totalWords: 14, blocksGroup1: 1, wordsBlockGroup1: 14, ecWordsBlock: 10},
+    {nversion: 4, micro: true, correction: CorrectionQ, capNum: 21, capAlpha: 13, capByte: 9, capKanji: 5, totalWords: 10, blocksGroup1: 1, wordsBlockGroup1: 10, ecWordsBlock: 14},
+  }
+}
+
+// buildMicroFunctionPatterns creates the matrix of a Micro symbol: one
+// finder in the top left corner, the timing patterns along the top and left
+// edges and the format area around the finder's separator
+func buildMicroFunctionPatterns(version Version) *Matrix {
+  size := symbolSize(version)
+  m := newMatrix(size, size)
+  m.micro = true
+
+  for i := 0; i < size; i++ {
+    m.setFunction(0, i, i%2 == 0)
+    m.setFunction(i, 0, i%2 == 0)
+  }
+
+  m.placeFinder(0, 0)
+
+  for i := 1; i <= 8; i++ {
+    m.setFunction(8, i, false)
+    m.setFunction(i, 8, false)
+  }
+
+  return m
+}
+
+// microSymbolNumber identifies the version and level in the format
+// information, from 0 for M1 to 7 for M4-Q
+func microSymbolNumber(version Version) uint32 {
+  first := []uint32{0, 1, 3, 5}[version.nversion-1]
+  switch version.correction {
+  case CorrectionM:
+    return first + 1
+  case CorrectionQ:
+    return first + 2
+  }
+  return first
+}
+
+// microFormatBits computes the 15 bit format information of Micro symbols:
+// symbol number and mask, their BCH(15,5) code and then XORed with
+// 100010001000101
+func microFormatBits(version Version, mask int) uint32 {
+  data := microSymbolNumber(version) << 2 | uint32(mask)
+  return (data << 10 | bchRemainder(data, 0b10100110111)) ^ 0b100010001000101
+}
+
+// placeMicroFormat writes the format information down column 8 (bits 0 to
+// 7) and along row 8 from the left (bits 14 to 7)
+func placeMicroFormat(m *Matrix, version Version, mask int) {
+  bits := microFormatBits(version, mask)
+  bit := func(i int) bool {
+    return (bits >> i) & 1 == 1
+  }
+
+  for i := 0; i < 8; i++ {
+    m.setFunction(i+1, 8, bit(i))
+    m.setFunction(8, i+1, bit(14-i))
+  }
+}
+
+// microMaskScore evaluates a masked Micro symbol by the dark modules on the
+// right and bottom edges (without the timing patterns): the lower count
+// times 16 plus the higher one, the highest score wins
+func microMaskScore(m *Matrix) int {
+  right, bottom := 0, 0
+  for i := 1; i < m.width; i++ {
+    if m.modules[i][m.width-1] {
+      right++
+    }
+    if m.modules[m.height-1][i] {
+      bottom++
+    }
+  }
+  if right <= bottom {
+    return right*16 + bottom
+  }
+  return bottom*16 + right
+}
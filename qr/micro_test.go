@@ -0,0 +1,125 @@
+package qr
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMicroFormatBits(t *testing.T) {
+  //by symbol number: M1, M2-L, M2-M, M3-L, M3-M, M4-L, M4-M, M4-Q
+  tests := []struct {
+    nversion int
+    level CorrectionLevel
+    masks [4]uint32
+  }{
+    {1, CorrectionL, [4]uint32{0x4445, 0x4172, 0x4E2B, 0x4B1C}},
+    {2, CorrectionL, [4]uint32{0x55AE, 0x5099, 0x5FC0, 0x5AF7}},
+    {2, CorrectionM, [4]uint32{0x6793, 0x62A4, 0x6DFD, 0x68CA}},
+    {3, CorrectionL, [4]uint32{0x7678, 0x734F, 0x7C16, 0x7921}},
+    {3, CorrectionM, [4]uint32{0x06DE, 0x03E9, 0x0CB0, 0x0987}},
+    {4, CorrectionL, [4]uint32{0x1735, 0x1202, 0x1D5B, 0x186C}},
+    {4, CorrectionM, [4]uint32{0x2508, 0x203F, 0x2F66, 0x2A51}},
+    {4, CorrectionQ, [4]uint32{0x34E3, 0x31D4, 0x3E8D, 0x3BBA}},
+  }
+  for _, test := range tests {
+    version, err := findVersion(test.nversion, test.level, true)
+    if err != nil {
+      t.Fatal(err)
+    }
+    for mask, want := range test.masks {
+      if got := microFormatBits(version, mask); got != want {
+        t.Errorf("M%d-%c mask %d: got %015b, want %015b", test.nversion, test.level, mask, got, want)
+      }
+    }
+  }
+}
+
+// goldenM2Format is a blank M2 symbol with the format information of M2-L
+// mask 1 (101000010011001): bits 14 to 7 along row 8 from column 1, bits 0
+// to 7 down column 8 from row 1
+const goldenM2Format = `#######.#.#.#
+#.....#.#....
+#.###.#......
+#.###.#......
+#.###.#.#....
+#.....#.#....
+#######......
+.............
+##.#....#....
+.............
+#............
+.............
+#............
+`
+
+func TestPlaceMicroFormatGolden(t *testing.T) {
+  version, err := findVersion(2, CorrectionL, true)
+  if err != nil {
+    t.Fatal(err)
+  }
+  m := buildFunctionPatterns(version)
+  placeMicroFormat(m, version, 1)
+  if got := gridString(m.modules); got != goldenM2Format {
+    t.Errorf("got\n%swant\n%s", got, goldenM2Format)
+  }
+  for i := 1; i <= 8; i++ {
+    if !m.function[8][i] || !m.function[i][8] {
+      t.Errorf("format module %d isn't a function module", i)
+    }
+  }
+}
+
+func TestEncodeMicroISO(t *testing.T) {
+  //the Micro QR example of ISO/IEC 18004 Annex I: "01234567" in M2-L
+  version, err := findVersion(2, CorrectionL, true)
+  if err != nil {
+    t.Fatal(err)
+  }
+  encoded, err := encode([]Segment{{mode: Numeric, data: "01234567"}}, version, CorrectionL, StructuredAppend{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if want := []byte{0x40, 0x18, 0xAC, 0xC3, 0x00}; !bytes.Equal(encoded, want) {
+    t.Errorf("data codewords % X, want % X", encoded, want)
+  }
+  blocks := errorCorrection(encoded, version)
+  if want := []byte{0x86, 0x0D, 0x22, 0xAE, 0x30}; len(blocks) != 1 || !bytes.Equal(blocks[0].ec, want) {
+    t.Errorf("error correction % X, want % X", blocks[0].ec, want)
+  }
+}
+
+func TestMicroMaskScores(t *testing.T) {
+  options := DefaultOptions()
+  options.Micro = true
+  options.Level = CorrectionL
+  symbol, err := Encode("01234567", options)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !symbol.Micro || len(symbol.MaskScores) != 4 {
+    t.Fatalf("got a Micro %t symbol with %d scores", symbol.Micro, len(symbol.MaskScores))
+  }
+
+  best := symbol.MaskScores[0]
+  for _, score := range symbol.MaskScores {
+    if score.Penalties != [4]int{} || score.Total != -score.MicroScore || score.MicroScore <= 0 {
+      t.Errorf("score %+v", score)
+    }
+    if score.MicroScore > best.MicroScore {
+      best = score
+    }
+  }
+  if symbol.Mask != best.Mask {
+    t.Errorf("got mask %d, the highest score is mask %d in %+v", symbol.Mask, best.Mask, symbol.MaskScores)
+  }
+}
+
+func TestMicroInvalidMask(t *testing.T) {
+  options := DefaultOptions()
+  options.Micro = true
+  options.Version = 2
+  options.Mask = 4
+  if _, err := Encode("1", options); err != ErrInvalidMask {
+    t.Errorf("got %v, want ErrInvalidMask", err)
+  }
+}
@@ -0,0 +1,125 @@
+package qr
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// DefaultQuietZone is the light margin, in modules, that the spec asks for
+// around regular symbols so readers can tell where they start. Micro QR and
+// rMQR symbols only need 2 modules
+const DefaultQuietZone = 4
+
+// PNGOptions configures Image and WritePNG
+type PNGOptions struct {
+  // ModuleSize is the side of each module in pixels
+  ModuleSize int
+  // QuietZone is the margin in modules, see DefaultQuietZone
+  QuietZone int
+  Colors
+  Style
+  // Logo is drawn scaled over the symbol's logo area, if any
+  Logo image.Image
+}
+
+// DefaultPNGOptions uses 8 pixel black on white modules and
+// DefaultQuietZone
+func DefaultPNGOptions() PNGOptions {
+  return PNGOptions{ModuleSize: 8, QuietZone: DefaultQuietZone, Colors: DefaultColors()}
+}
+
+// Image draws the symbol in a paletted image, index 0 is the background and
+// index 1 the foreground. The finder ring and eye colors follow when the
+// style sets them.
+// Shapes are sampled at the center of each pixel, small module sizes make
+// round shapes look square.
+// The Logo in the options isn't drawn, a paletted image can't hold its
+// colors: WritePNG draws it over a full color copy
+func (s *Symbol) Image(options PNGOptions) *image.Paletted {
+  width := (s.Width + 2*options.QuietZone) * options.ModuleSize
+  height := (s.Height + 2*options.QuietZone) * options.ModuleSize
+  palette := color.Palette{options.background(), options.foreground()}
+  var ring, eye uint8 = 1, 1
+  if options.RingColor != nil {
+    palette = append(palette, options.RingColor)
+    ring = uint8(len(palette) - 1)
+  }
+  if options.EyeColor != nil {
+    palette = append(palette, options.EyeColor)
+    eye = uint8(len(palette) - 1)
+  }
+  img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+
+  //fill calls inside with the position of each pixel center in modules,
+  //relative to the top left corner of the area
+  fill := func(row, col, modules int, index uint8, inside func(x, y float64) bool) {
+    y0 := (row + options.QuietZone) * options.ModuleSize
+    x0 := (col + options.QuietZone) * options.ModuleSize
+    for y := 0; y < modules*options.ModuleSize; y++ {
+      for x := 0; x < modules*options.ModuleSize; x++ {
+        fx := (float64(x) + 0.5) / float64(options.ModuleSize)
+        fy := (float64(y) + 0.5) / float64(options.ModuleSize)
+        if inside(fx, fy) {
+          img.SetColorIndex(x0+x, y0+y, index)
+        }
+      }
+    }
+  }
+
+  for row := 0; row < s.Height; row++ {
+    for col := 0; col < s.Width; col++ {
+      if _, _, finder := finderOrigin(s, row, col); finder || !s.Modules[row][col] {
+        continue
+      }
+      corners := moduleRadii(s, options.Modules, row, col)
+      fill(row, col, 1, 1, func(x, y float64) bool {
+        return insideRoundedRect(x, y, 1, 1, corners)
+      })
+    }
+  }
+
+  outer, inner, center := finderRadii(options.FinderRing, options.FinderEye)
+  for _, origin := range s.finderOrigins() {
+    fill(origin[0], origin[1], 7, ring, func(x, y float64) bool {
+      return insideRoundedRect(x, y, 7, 7, outer) && !insideRoundedRect(x-1, y-1, 5, 5, inner)
+    })
+    fill(origin[0], origin[1], 7, eye, func(x, y float64) bool {
+      return insideRoundedRect(x-2, y-2, 3, 3, center)
+    })
+  }
+
+  return img
+}
+
+// WritePNG encodes the symbol as a PNG, with a 2 color palette it is
+// written with 1 bit per pixel. Transparency is kept
+func WritePNG(w io.Writer, symbol *Symbol, options PNGOptions) error {
+  if options.ModuleSize < 1 || options.QuietZone < 0 {
+    return ErrInvalidImageOptions
+  }
+  if err := options.Style.validate(); err != nil {
+    return err
+  }
+  if err := options.checkContrast(options.Colors); err != nil {
+    return err
+  }
+  if options.Logo == nil {
+    return png.Encode(w, symbol.Image(options))
+  }
+  if symbol.Logo.Size == 0 {
+    return ErrNoLogoArea
+  }
+
+  paletted := symbol.Image(options)
+  img := image.NewNRGBA(paletted.Bounds())
+  draw.Draw(img, img.Bounds(), paletted, image.Point{}, draw.Src)
+  start := func(modules int) int {
+    return (modules + options.QuietZone) * options.ModuleSize
+  }
+  area := image.Rect(start(symbol.Logo.Col), start(symbol.Logo.Row), start(symbol.Logo.Col+symbol.Logo.Size), start(symbol.Logo.Row+symbol.Logo.Size))
+  drawLogo(img, area, options.Logo, options.background())
+  return png.Encode(w, img)
+}
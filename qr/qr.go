@@ -0,0 +1,1007 @@
+// Package qr encodes data as QR code symbols
+package qr
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type EncodingMode int
+const (
+  Numeric EncodingMode = iota // 0001
+  Alphanumeric // 0010
+  Byte // 0100
+  Kanji // 1000
+)
+
+// AutoMode lets the encoder split the data in the optimal segments
+const AutoMode EncodingMode = -1
+
+func (m EncodingMode) String() string {
+  switch m {
+  case Numeric:
+    return "numeric"
+  case Alphanumeric:
+    return "alphanumeric"
+  case Byte:
+    return "byte"
+  case Kanji:
+    return "kanji"
+  }
+  return fmt.Sprintf("EncodingMode(%d)", int(m))
+}
+
+type CorrectionLevel rune
+const (
+  CorrectionL CorrectionLevel = 'L'
+  CorrectionM CorrectionLevel = 'M'
+  CorrectionQ CorrectionLevel = 'Q'
+  CorrectionH CorrectionLevel = 'H'
+)
+
+func (c CorrectionLevel) valid() bool {
+  return c == CorrectionL || c == CorrectionM || c == CorrectionQ || c == CorrectionH
+}
+
+type Version struct {
+  nversion int
+  // micro versions are M1-M4, nversion is 1-4 for them
+  micro bool
+  // rmqr versions are the rectangular Micro QR sizes, nversion is their
+  // index from 0 (R7x43) to 31 (R17x139)
+  rmqr bool
+  height int
+  width int
+  // countLengths and remainder are irregular in rmqr versions, they are
+  // listed for each one
+  countLengths [4]int
+  remainder int
+  correction CorrectionLevel
+  capNum int
+  capAlpha int
+  capByte int
+  capKanji int
+  totalWords int
+  blocksGroup1 int
+  wordsBlockGroup1 int
+  blocksGroup2 int
+  wordsBlockGroup2 int
+  ecWordsBlock int
+}
+
+// CharCountLength is the width of the character count indicator for the
+// mode, 0 when the mode isn't available in the version (M1 only has numeric
+// mode, M2 adds alphanumeric)
+func (v Version) CharCountLength(mode EncodingMode) int {
+  if v.rmqr {
+    if mode < Numeric || mode > Kanji {
+      return 0
+    }
+    return v.countLengths[mode]
+  }
+  if v.micro {
+    switch {
+    case mode == Numeric:
+      return v.nversion + 2
+    case mode == Alphanumeric && v.nversion >= 2:
+      return v.nversion + 1
+    case mode == Byte && v.nversion >= 3:
+      return v.nversion + 1
+    case mode == Kanji && v.nversion >= 3:
+      return v.nversion
+    }
+    return 0
+  }
+
+  if v.nversion < 10 {
+    switch mode {
+    case Numeric:
+      return 10
+    case Alphanumeric:
+      return 9
+    case Byte:
+      return 8
+    case Kanji:
+      return 8
+    }
+  } else if v.nversion < 27 {
+    switch mode {
+    case Numeric:
+      return 12
+    case Alphanumeric:
+      return 11
+    case Byte:
+      return 16
+    case Kanji:
+      return 10
+    }
+  } else {
+    switch mode {
+    case Numeric:
+      return 14
+    case Alphanumeric:
+      return 13
+    case Byte:
+      return 16
+    case Kanji:
+      return 12
+    }
+  }
+  return 0
+}
+
+// supports tells if the mode can be used in the version
+func (v Version) supports(mode EncodingMode) bool {
+  return v.CharCountLength(mode) > 0
+}
+
+// ModeIndicatorLength is the width of the mode indicator: 4 bits, from
+// none in M1 to 3 bits in M4, and 3 bits in rMQR
+func (v Version) ModeIndicatorLength() int {
+  if v.rmqr {
+    return 3
+  }
+  if v.micro {
+    return v.nversion - 1
+  }
+  return 4
+}
+
+// modeIndicator is 0001, 0010, 0100 or 1000, Micro QR numbers the modes
+// in order instead, from 1 in rMQR
+func (v Version) modeIndicator(mode EncodingMode) uint32 {
+  if v.rmqr {
+    return uint32(mode) + 1
+  }
+  if v.micro {
+    return uint32(mode)
+  }
+  return 1 << mode
+}
+
+// dataBits is the capacity for data, in M1 and M3 the last data codeword
+// is only 4 bits long
+func (v Version) dataBits() int {
+  if v.micro && v.nversion%2 == 1 {
+    return v.totalWords*8 - 4
+  }
+  return v.totalWords * 8
+}
+
+// terminatorLength is the number of 0s that end the data: 4, from 3 in M1
+// to 9 in M4, and 3 in rMQR
+func (v Version) terminatorLength() int {
+  if v.rmqr {
+    return 3
+  }
+  if v.micro {
+    return 2*v.nversion + 1
+  }
+  return 4
+}
+
+// RemainderBits is the number of 0 bits appended after the final codeword
+// to fill the symbol
+func (v Version) RemainderBits() int {
+  if v.rmqr {
+    return v.remainder
+  }
+  if v.micro {
+    return 0
+  }
+  switch {
+  case v.nversion >= 2 && v.nversion <= 6:
+    return 7
+  case v.nversion >= 14 && v.nversion <= 20:
+    return 3
+  case v.nversion >= 21 && v.nversion <= 27:
+    return 4
+  case v.nversion >= 28 && v.nversion <= 34:
+    return 3
+  }
+  return 0
+}
+
+// Options configures the encoding, start from DefaultOptions
+type Options struct {
+  // Level is the error correction level
+  Level CorrectionLevel
+  // Version forces the symbol version (1-40), 0 picks the smallest that fits
+  Version int
+  // Mask forces the mask pattern (0-7, 0-3 for Micro QR), AutoMask picks the
+  // lowest penalty
+  Mask int
+  // Mode forces all the data in a single encoding mode, AutoMode mixes them
+  // to get the shortest encoding
+  Mode EncodingMode
+  // LogoSize reserves a centered square for a logo, as a fraction of the
+  // symbol side (0 for no logo). The level is raised to H when the modules
+  // under it can't be recovered otherwise, and with Version 0 a larger
+  // version is picked when the logo doesn't fit the smallest one. Versions
+  // 7-13, 21-27 and 35-40 have an alignment pattern in the center and can't
+  // hold one
+  LogoSize float64
+  // Micro allows Micro QR symbols (M1-M4): with Version 0 the smallest
+  // Micro or regular symbol that fits is picked, Version 1-4 forces that
+  // Micro version. M1 only detects errors and is used for level L, M2 and
+  // M3 go up to level M and M4 up to Q. They have 4 masks and no logo
+  Micro bool
+  // MaxHeight makes a rectangular Micro QR (rMQR) symbol no taller than
+  // MaxHeight modules (7-17), the one with the smallest area that fits is
+  // picked, the shortest on ties. They only have levels M and H, a single
+  // mask and no logo
+  MaxHeight int
+}
+
+// DefaultOptions uses correction level M, picks the smallest version and
+// the best mask, and mixes encoding modes
+func DefaultOptions() Options {
+  return Options{Level: CorrectionM, Version: 0, Mask: AutoMask, Mode: AutoMode}
+}
+
+// Symbol is an encoded QR code
+type Symbol struct {
+  // Version is 1-40, 1-4 for Micro symbols or the index of the size from 0
+  // (R7x43) to 31 (R17x139) for rMQR symbols
+  Version int
+  // Micro tells if it is a Micro QR symbol, with a single finder pattern
+  Micro bool
+  // Rect tells if it is a rectangular Micro QR (rMQR) symbol
+  Rect bool
+  Level CorrectionLevel
+  Mask int
+  // MaskScores are the penalties of every mask pattern, to audit the choice.
+  // Micro symbols have 4 scored by MicroScore, rMQR symbols have a single
+  // mask and no scores
+  MaskScores []MaskScore
+  // Size is the width and height in modules of square symbols, without
+  // quiet zone. It's 0 for rMQR symbols
+  Size int
+  // Width and Height are the dimensions in modules, without quiet zone
+  Width int
+  Height int
+  // Modules is indexed by [row][col], true is a dark module
+  Modules [][]bool
+  // Function is indexed like Modules, true marks the modules of function
+  // patterns (finders, separators, timing, alignment, format and version
+  // information) that the module shapes leave square
+  Function [][]bool
+  // Logo is the area reserved for a logo, its Size is 0 when there's none
+  Logo LogoArea
+  // Append places the symbol in a structured append sequence, its Total is
+  // 0 when the symbol holds all the data
+  Append StructuredAppend
+}
+
+// Encode builds the QR code for the text, splitting it in the segments
+// that make it shortest
+func Encode(data string, options Options) (*Symbol, error) {
+  if err := options.validate(); err != nil {
+    return nil, err
+  }
+
+  return withLogoBudget(options, func(options Options) (*Symbol, error) {
+    return encodeText(data, options)
+  })
+}
+
+func encodeText(data string, options Options) (*Symbol, error) {
+  if options.Mode != AutoMode {
+    return encodeSingleMode(data, options)
+  }
+
+  var version Version
+  var segments []Segment
+  var err error
+  if options.Version == 0 {
+    version, segments, err = determineSegmentedVersion(data, options.Level, candidateVersions(options))
+  } else {
+    version, err = findVersion(options.Version, options.Level, options.Micro)
+    if err == nil {
+      segments, err = optimizeSegments(data, version)
+    }
+  }
+  if err != nil {
+    return nil, err
+  }
+
+  return encodeSymbol(segments, version, options, StructuredAppend{})
+}
+
+// EncodeBytes builds the QR code for binary data, all in byte mode
+func EncodeBytes(data []byte, options Options) (*Symbol, error) {
+  if err := options.validate(); err != nil {
+    return nil, err
+  }
+
+  options.Mode = Byte
+  return withLogoBudget(options, func(options Options) (*Symbol, error) {
+    return encodeSingleMode(string(data), options)
+  })
+}
+
+// encodeSingleMode encodes all the data as one segment in options.Mode
+func encodeSingleMode(data string, options Options) (*Symbol, error) {
+  segments := []Segment{{mode: options.Mode, data: data}}
+  var version Version
+  var err error
+  if options.Version == 0 {
+    version, err = determineVersion(data, options.Level, options.Mode, candidateVersions(options))
+  } else {
+    version, err = findVersion(options.Version, options.Level, options.Micro)
+  }
+  if err != nil {
+    return nil, err
+  }
+
+  return encodeSymbol(segments, version, options, StructuredAppend{})
+}
+
+func (o Options) validate() error {
+  if !o.Level.valid() {
+    return ErrInvalidLevel
+  }
+  if o.Version < 0 || o.Version > 40 {
+    return ErrInvalidVersion
+  }
+  if o.Mask != AutoMask && (o.Mask < 0 || o.Mask > 7) {
+    return ErrInvalidMask
+  }
+  if o.Mode != AutoMode && (o.Mode < Numeric || o.Mode > Kanji) {
+    return ErrInvalidMode
+  }
+  if o.LogoSize < 0 || o.LogoSize >= 1 {
+    return ErrInvalidLogoSize
+  }
+  if o.Micro && o.Version > 4 {
+    return ErrInvalidVersion
+  }
+  if o.Micro && o.Version > 0 && o.Mask > 3 {
+    return ErrInvalidMask
+  }
+  if o.Micro && o.Version > 0 && o.LogoSize > 0 {
+    return ErrInvalidLogoSize
+  }
+  if o.MaxHeight != 0 {
+    switch {
+    case o.MaxHeight < 7 || o.MaxHeight > 17 || o.Version != 0 || o.Micro:
+      return ErrInvalidHeight
+    case o.Level != CorrectionM && o.Level != CorrectionH:
+      return ErrUnsupportedLevel
+    case o.Mask != AutoMask:
+      return ErrInvalidMask
+    case o.LogoSize > 0:
+      return ErrInvalidLogoSize
+    }
+  }
+  return nil
+}
+
+// allowsMicro tells if the automatic version selection can pick Micro
+// symbols, not when the mask or the logo need a regular one
+func (o Options) allowsMicro() bool {
+  return o.Micro && o.Mask <= 3 && o.LogoSize == 0
+}
+
+// encodeSymbol runs the whole pipeline once the version is known: data
+// codewords, error correction, interleaving, placement and masking. part is
+// the structured append header, if any
+func encodeSymbol(segments []Segment, version Version, options Options, part StructuredAppend) (*Symbol, error) {
+  encoded, err := encode(segments, version, options.Level, part)
+  if err != nil {
+    return nil, err
+  }
+
+  blocks := errorCorrection(encoded, version)
+  message := interleave(blocks, version)
+
+  matrix := buildFunctionPatterns(version)
+  placeData(matrix, message)
+
+  logo := LogoArea{}
+  if options.LogoSize > 0 {
+    logo, err = reserveLogo(matrix, version, options.LogoSize)
+    if err != nil {
+      return nil, err
+    }
+  }
+
+  mask, scores := selectMask(matrix, version, options.Mask)
+
+  size := matrix.Width()
+  if version.rmqr {
+    size = 0
+  }
+
+  return &Symbol{
+    Version: version.nversion,
+    Micro: version.micro,
+    Rect: version.rmqr,
+    Level: options.Level,
+    Mask: mask,
+    MaskScores: scores,
+    Size: size,
+    Width: matrix.Width(),
+    Height: matrix.Height(),
+    Modules: matrix.modules,
+    Function: matrix.function,
+    Logo: logo,
+    Append: part,
+  }, nil
+}
+
+// findVersion returns the entry of listVersions, or listMicroVersions, for
+// the number and level
+func findVersion(nversion int, correction CorrectionLevel, micro bool) (Version, error) {
+  if !correction.valid() {
+    return Version{}, ErrInvalidLevel
+  }
+  versions := listVersions()
+  if micro {
+    versions = listMicroVersions()
+  }
+  found := false
+  for _, v := range versions {
+    if v.nversion == nversion {
+      found = true
+      if v.correction == correction {
+        return v, nil
+      }
+    }
+  }
+  if found {
+    return Version{}, ErrUnsupportedLevel
+  }
+  return Version{}, ErrInvalidVersion
+}
+
+// candidateVersions lists the versions the options can use from the
+// smallest: only rMQR ones with a MaxHeight, Micro ones first when they are
+// allowed
+func candidateVersions(options Options) []Version {
+  switch {
+  case options.MaxHeight > 0:
+    return rectVersions(options.MaxHeight)
+  case options.allowsMicro():
+    return append(listMicroVersions(), listVersions()...)
+  }
+  return listVersions()
+}
+
+func encodingFormat(input string) EncodingMode {
+  //kanji only when every character has a shift jis kanji value
+  if isKanji(input) {
+    return Kanji
+  }
+
+  table := alphaTranslator()
+  mode := Numeric
+
+  for _, char := range input {
+    if char < '0' || char > '9' {
+      //is not numeric then
+      if _, isAlpha := table[char]; isAlpha {
+        mode = Alphanumeric
+      } else {
+        mode = Byte
+        break
+      }
+    }
+  }
+
+  return mode
+}
+
+func listVersions() []Version {
+  return []Version{
+    {nversion: 1, correction: CorrectionL, capNum: 41, capAlpha: 25, capByte: 17, capKanji: 10, totalWords: 19, blocksGroup1: 1, wordsBlockGroup1: 19, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 7},
+    {nversion: 1, correction: CorrectionM, capNum: 34, capAlpha: 20, capByte: 14, capKanji: 8, totalWords: 16, blocksGroup1: 1, wordsBlockGroup1: 16, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 10},
+    {nversion: 1, correction: CorrectionQ, capNum: 27, capAlpha: 16, capByte: 11, capKanji: 7, totalWords: 13, blocksGroup1: 1, wordsBlockGroup1: 13, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 13},
+    {nversion: 1, correction: CorrectionH, capNum: 17, capAlpha: 10, capByte: 7, capKanji: 4, totalWords: 9, blocksGroup1: 1, wordsBlockGroup1: 9, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 17},
+
+    {nversion: 2 ,correction: CorrectionL, capNum: 77, capAlpha: 47, capByte: 32, capKanji: 20, totalWords: 34, blocksGroup1: 1, wordsBlockGroup1: 34, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 10},
+    {nversion: 2 ,correction: CorrectionM, capNum: 63, capAlpha: 38, capByte: 26, capKanji: 16, totalWords: 28, blocksGroup1: 1, wordsBlockGroup1: 28, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 16},
+    {nversion: 2 ,correction: CorrectionQ, capNum: 48, capAlpha: 29, capByte: 20, capKanji: 12, totalWords: 22, blocksGroup1: 1, wordsBlockGroup1: 22, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22},
+    {nversion: 2 ,correction: CorrectionH, capNum: 34, capAlpha: 20, capByte: 14, capKanji: 8, totalWords: 16, blocksGroup1: 1, wordsBlockGroup1: 16, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 28},
+
+    {nversion: 3 ,correction: CorrectionL, capNum: 127, capAlpha: 77, capByte: 53, capKanji: 32, totalWords: 55, blocksGroup1: 1, wordsBlockGroup1: 55, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 15},
+    {nversion: 3 ,correction: CorrectionM, capNum: 101, capAlpha: 61, capByte: 42, capKanji: 26, totalWords: 44, blocksGroup1: 1, wordsBlockGroup1: 44, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26},
+    {nversion: 3 ,correction: CorrectionQ, capNum: 77, capAlpha: 47, capByte: 32, capKanji: 20, totalWords: 34, blocksGroup1: 2, wordsBlockGroup1: 17, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 18},
+    {nversion: 3 ,correction: CorrectionH, capNum: 58, capAlpha: 35, capByte: 24, capKanji: 15, totalWords: 26, blocksGroup1: 2, wordsBlockGroup1: 13, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22},
+
+    {nversion: 4 ,correction: CorrectionL, capNum: 187, capAlpha: 114, capByte: 78, capKanji: 48, totalWords: 80, blocksGroup1: 1, wordsBlockGroup1: 80, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 20},
+    {nversion: 4 ,correction: CorrectionM, capNum: 149, capAlpha: 90, capByte: 62, capKanji: 38, totalWords: 64, blocksGroup1: 2, wordsBlockGroup1: 32, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 18},
+    {nversion: 4 ,correction: CorrectionQ, capNum: 111, capAlpha: 67, capByte: 46, capKanji: 28, totalWords: 48, blocksGroup1: 2, wordsBlockGroup1: 24, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26},
+    {nversion: 4 ,correction: CorrectionH, capNum: 82, capAlpha: 50, capByte: 34, capKanji: 21, totalWords: 36, blocksGroup1: 4, wordsBlockGroup1: 9, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 16},
+
+    {nversion: 5 ,correction: CorrectionL, capNum: 255, capAlpha: 154, capByte: 106, capKanji: 65, totalWords: 108, blocksGroup1: 1, wordsBlockGroup1: 108, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26},
+    {nversion: 5 ,correction: CorrectionM, capNum: 202, capAlpha: 122, capByte: 84, capKanji: 52, totalWords: 86, blocksGroup1: 2, wordsBlockGroup1: 43, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24},
+    {nversion: 5 ,correction: CorrectionQ, capNum: 144, capAlpha: 87, capByte: 60, capKanji: 37, totalWords: 62, blocksGroup1: 2, wordsBlockGroup1: 15, blocksGroup2: 2, wordsBlockGroup2: 16 , ecWordsBlock: 18},
+    {nversion: 5 ,correction: CorrectionH, capNum: 106, capAlpha: 64, capByte: 44, capKanji: 27, totalWords: 46, blocksGroup1: 2, wordsBlockGroup1: 11, blocksGroup2: 2, wordsBlockGroup2: 12 , ecWordsBlock: 22},
+
+    {nversion: 6 ,correction: CorrectionL, capNum: 322, capAlpha: 195, capByte: 134, capKanji: 82, totalWords: 136, blocksGroup1: 2, wordsBlockGroup1: 68, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 18},
+    {nversion: 6 ,correction: CorrectionM, capNum: 255, capAlpha: 154, capByte: 106, capKanji: 65, totalWords: 108, blocksGroup1: 4, wordsBlockGroup1: 27, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 16},
+    {nversion: 6 ,correction: CorrectionQ, capNum: 178, capAlpha: 108, capByte: 74, capKanji: 45, totalWords: 76, blocksGroup1: 4, wordsBlockGroup1: 19, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24},
+    {nversion: 6 ,correction: CorrectionH, capNum: 139, capAlpha: 84, capByte: 58, capKanji: 36, totalWords: 60, blocksGroup1: 4, wordsBlockGroup1: 15, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 28},
+
+    {nversion: 7 ,correction: CorrectionL, capNum: 370, capAlpha: 224, capByte: 154, capKanji: 95, totalWords: 156, blocksGroup1: 2, wordsBlockGroup1: 78, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 20},
+    {nversion: 7 ,correction: CorrectionM, capNum: 293, capAlpha: 178, capByte: 122, capKanji: 75, totalWords: 124, blocksGroup1: 4, wordsBlockGroup1: 31, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 18},
+    {nversion: 7 ,correction: CorrectionQ, capNum: 207, capAlpha: 125, capByte: 86, capKanji: 53, totalWords: 88, blocksGroup1: 2, wordsBlockGroup1: 14, blocksGroup2: 4, wordsBlockGroup2: 15 , ecWordsBlock: 18},
+    {nversion: 7 ,correction: CorrectionH, capNum: 154, capAlpha: 93, capByte: 64, capKanji: 39, totalWords: 66, blocksGroup1: 4, wordsBlockGroup1: 13, blocksGroup2: 1, wordsBlockGroup2: 14 , ecWordsBlock: 26},
+
+    {nversion: 8 ,correction: CorrectionL, capNum: 461, capAlpha: 279, capByte: 192, capKanji: 118, totalWords: 194, blocksGroup1: 2, wordsBlockGroup1: 97, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24},
+    {nversion: 8 ,correction: CorrectionM, capNum: 365, capAlpha: 221, capByte: 152, capKanji: 93, totalWords: 154, blocksGroup1: 2, wordsBlockGroup1: 38, blocksGroup2: 2, wordsBlockGroup2: 39 , ecWordsBlock: 22},
+    {nversion: 8 ,correction: CorrectionQ, capNum: 259, capAlpha: 157, capByte: 108, capKanji: 66, totalWords: 110, blocksGroup1: 4, wordsBlockGroup1: 18, blocksGroup2: 2, wordsBlockGroup2: 19 , ecWordsBlock: 22},
+    {nversion: 8 ,correction: CorrectionH, capNum: 202, capAlpha: 122, capByte: 84, capKanji: 52, totalWords: 86, blocksGroup1: 4, wordsBlockGroup1: 14, blocksGroup2: 2, wordsBlockGroup2: 15 , ecWordsBlock: 26},
+
+    {nversion: 9 ,correction: CorrectionL, capNum: 552, capAlpha: 335, capByte: 230, capKanji: 141, totalWords: 232, blocksGroup1: 2, wordsBlockGroup1: 116, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 30},
+    {nversion: 9 ,correction: CorrectionM, capNum: 432, capAlpha: 262, capByte: 180, capKanji: 111, totalWords: 182, blocksGroup1: 3, wordsBlockGroup1: 36, blocksGroup2: 2, wordsBlockGroup2: 37 , ecWordsBlock: 22},
+    {nversion: 9 ,correction: CorrectionQ, capNum: 312, capAlpha: 189, capByte: 130, capKanji: 80, totalWords: 132, blocksGroup1: 4, wordsBlockGroup1: 16, blocksGroup2: 4, wordsBlockGroup2: 17 , ecWordsBlock: 20},
+    {nversion: 9 ,correction: CorrectionH, capNum: 235, capAlpha: 143, capByte: 98, capKanji: 60, totalWords: 100, blocksGroup1: 4, wordsBlockGroup1: 12, blocksGroup2: 4, wordsBlockGroup2: 13 , ecWordsBlock: 24},
+
+    {nversion: 10 ,correction: CorrectionL, capNum: 652, capAlpha: 395, capByte: 271, capKanji: 167, totalWords: 274, blocksGroup1: 2, wordsBlockGroup1: 68, blocksGroup2: 2, wordsBlockGroup2: 69 , ecWordsBlock: 18},
+    {nversion: 10 ,correction: CorrectionM, capNum: 513, capAlpha: 311, capByte: 213, capKanji: 131, totalWords: 216, blocksGroup1: 4, wordsBlockGroup1: 43, blocksGroup2: 1, wordsBlockGroup2: 44 , ecWordsBlock: 26},
+    {nversion: 10 ,correction: CorrectionQ, capNum: 364, capAlpha: 221, capByte: 151, capKanji: 93, totalWords: 154, blocksGroup1: 6, wordsBlockGroup1: 19, blocksGroup2: 2, wordsBlockGroup2: 20 , ecWordsBlock: 24},
+    {nversion: 10 ,correction: CorrectionH, capNum: 288, capAlpha: 174, capByte: 119, capKanji: 74, totalWords: 122, blocksGroup1: 6, wordsBlockGroup1: 15, blocksGroup2: 2, wordsBlockGroup2: 16 , ecWordsBlock: 28},
+
+    {nversion: 11 ,correction: CorrectionL, capNum: 772, capAlpha: 468, capByte: 321, capKanji: 198, totalWords: 324, blocksGroup1: 4, wordsBlockGroup1: 81, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 20},
+    {nversion: 11 ,correction: CorrectionM, capNum: 604, capAlpha: 366, capByte: 251, capKanji: 155, totalWords: 254, blocksGroup1: 1, wordsBlockGroup1: 50, blocksGroup2: 4, wordsBlockGroup2: 51 , ecWordsBlock: 30},
+    {nversion: 11 ,correction: CorrectionQ, capNum: 427, capAlpha: 259, capByte: 177, capKanji: 109, totalWords: 180, blocksGroup1: 4, wordsBlockGroup1: 22, blocksGroup2: 4, wordsBlockGroup2: 23 , ecWordsBlock: 28},
+    {nversion: 11 ,correction: CorrectionH, capNum: 331, capAlpha: 200, capByte: 137, capKanji: 85, totalWords: 140, blocksGroup1: 3, wordsBlockGroup1: 12, blocksGroup2: 8, wordsBlockGroup2: 13 , ecWordsBlock: 24},
+
+    {nversion: 12 ,correction: CorrectionL, capNum: 883, capAlpha: 535, capByte: 367, capKanji: 226, totalWords: 370, blocksGroup1: 2, wordsBlockGroup1: 92, blocksGroup2: 2, wordsBlockGroup2: 93 , ecWordsBlock: 24},
+    {nversion: 12 ,correction: CorrectionM, capNum: 691, capAlpha: 419, capByte: 287, capKanji: 177, totalWords: 290, blocksGroup1: 6, wordsBlockGroup1: 36, blocksGroup2: 2, wordsBlockGroup2: 37 , ecWordsBlock: 22},
+    {nversion: 12 ,correction: CorrectionQ, capNum: 489, capAlpha: 296, capByte: 203, capKanji: 125, totalWords: 206, blocksGroup1: 4, wordsBlockGroup1: 20, blocksGroup2: 6, wordsBlockGroup2: 21 , ecWordsBlock: 26},
+    {nversion: 12 ,correction: CorrectionH, capNum: 374, capAlpha: 227, capByte: 155, capKanji: 96, totalWords: 158, blocksGroup1: 7, wordsBlockGroup1: 14, blocksGroup2: 4, wordsBlockGroup2: 15 , ecWordsBlock: 28},
+
+    {nversion: 13 ,correction: CorrectionL, capNum: 1022, capAlpha: 619, capByte: 425, capKanji: 262, totalWords: 428, blocksGroup1: 4, wordsBlockGroup1: 107, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26},
+    {nversion: 13 ,correction: CorrectionM, capNum: 796, capAlpha: 483, capByte: 331, capKanji: 204, totalWords: 334, blocksGroup1: 8, wordsBlockGroup1: 37, blocksGroup2: 1, wordsBlockGroup2: 38 , ecWordsBlock: 22},
+    {nversion: 13 ,correction: CorrectionQ, capNum: 580, capAlpha: 352, capByte: 241, capKanji: 149, totalWords: 244, blocksGroup1: 8, wordsBlockGroup1: 20, blocksGroup2: 4, wordsBlockGroup2: 21 , ecWordsBlock: 24},
+    {nversion: 13 ,correction: CorrectionH, capNum: 427, capAlpha: 259, capByte: 177, capKanji: 109, totalWords: 180, blocksGroup1: 12, wordsBlockGroup1: 11, blocksGroup2: 4, wordsBlockGroup2: 12 , ecWordsBlock: 22},
+
+    {nversion: 14 ,correction: CorrectionL, capNum: 1101, capAlpha: 667, capByte: 458, capKanji: 282, totalWords: 461, blocksGroup1: 3, wordsBlockGroup1: 115, blocksGroup2: 1, wordsBlockGroup2: 116 , ecWordsBlock: 30},
+    {nversion: 14 ,correction: CorrectionM, capNum: 871, capAlpha: 528, capByte: 362, capKanji: 223, totalWords: 365, blocksGroup1: 4, wordsBlockGroup1: 40, blocksGroup2: 5, wordsBlockGroup2: 41 , ecWordsBlock: 24},
+    {nversion: 14 ,correction: CorrectionQ, capNum: 621, capAlpha: 376, capByte: 258, capKanji: 159, totalWords: 261, blocksGroup1: 11, wordsBlockGroup1: 16, blocksGroup2: 5, wordsBlockGroup2: 17 , ecWordsBlock: 20},
+    {nversion: 14 ,correction: CorrectionH, capNum: 468, capAlpha: 283, capByte: 194, capKanji: 120, totalWords: 197, blocksGroup1: 11, wordsBlockGroup1: 12, blocksGroup2: 5, wordsBlockGroup2: 13 , ecWordsBlock: 24},
+
+    {nversion: 15 ,correction: CorrectionL, capNum: 1250, capAlpha: 758, capByte: 520, capKanji: 320, totalWords: 523, blocksGroup1: 5, wordsBlockGroup1: 87, blocksGroup2: 1, wordsBlockGroup2: 88 , ecWordsBlock: 22},
+    {nversion: 15 ,correction: CorrectionM, capNum: 991, capAlpha: 600, capByte: 412, capKanji: 254, totalWords: 415, blocksGroup1: 5, wordsBlockGroup1: 41, blocksGroup2: 5, wordsBlockGroup2: 42 , ecWordsBlock: 24},
+    {nversion: 15 ,correction: CorrectionQ, capNum: 703, capAlpha: 426, capByte: 292, capKanji: 180, totalWords: 295, blocksGroup1: 5, wordsBlockGroup1: 24, blocksGroup2: 7, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 15 ,correction: CorrectionH, capNum: 530, capAlpha: 321, capByte: 220, capKanji: 136, totalWords: 223, blocksGroup1: 11, wordsBlockGroup1: 12, blocksGroup2: 7, wordsBlockGroup2: 13 , ecWordsBlock: 24},
+
+    {nversion: 16 ,correction: CorrectionL, capNum: 1408, capAlpha: 854, capByte: 586, capKanji: 361, totalWords: 589, blocksGroup1: 5, wordsBlockGroup1: 98, blocksGroup2: 1, wordsBlockGroup2: 99 , ecWordsBlock: 24},
+    {nversion: 16 ,correction: CorrectionM, capNum: 1082, capAlpha: 656, capByte: 450, capKanji: 277, totalWords: 453, blocksGroup1: 7, wordsBlockGroup1: 45, blocksGroup2: 3, wordsBlockGroup2: 46 , ecWordsBlock: 28},
+    {nversion: 16 ,correction: CorrectionQ, capNum: 775, capAlpha: 470, capByte: 322, capKanji: 198, totalWords: 325, blocksGroup1: 15, wordsBlockGroup1: 19, blocksGroup2: 2, wordsBlockGroup2: 20 , ecWordsBlock: 24},
+    {nversion: 16 ,correction: CorrectionH, capNum: 602, capAlpha: 365, capByte: 250, capKanji: 154, totalWords: 253, blocksGroup1: 3, wordsBlockGroup1: 15, blocksGroup2: 13, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 17 ,correction: CorrectionL, capNum: 1548, capAlpha: 938, capByte: 644, capKanji: 397, totalWords: 647, blocksGroup1: 1, wordsBlockGroup1: 107, blocksGroup2: 5, wordsBlockGroup2: 108 , ecWordsBlock: 28},
+    {nversion: 17 ,correction: CorrectionM, capNum: 1212, capAlpha: 734, capByte: 504, capKanji: 310, totalWords: 507, blocksGroup1: 10, wordsBlockGroup1: 46, blocksGroup2: 1, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 17 ,correction: CorrectionQ, capNum: 876, capAlpha: 531, capByte: 364, capKanji: 224, totalWords: 367, blocksGroup1: 1, wordsBlockGroup1: 22, blocksGroup2: 15, wordsBlockGroup2: 23 , ecWordsBlock: 28},
+    {nversion: 17 ,correction: CorrectionH, capNum: 674, capAlpha: 408, capByte: 280, capKanji: 173, totalWords: 283, blocksGroup1: 2, wordsBlockGroup1: 14, blocksGroup2: 17, wordsBlockGroup2: 15 , ecWordsBlock: 28},
+
+    {nversion: 18 ,correction: CorrectionL, capNum: 1725, capAlpha: 1046, capByte: 718, capKanji: 442, totalWords: 721, blocksGroup1: 5, wordsBlockGroup1: 120, blocksGroup2: 1, wordsBlockGroup2: 121 , ecWordsBlock: 30},
+    {nversion: 18 ,correction: CorrectionM, capNum: 1346, capAlpha: 816, capByte: 560, capKanji: 345, totalWords: 563, blocksGroup1: 9, wordsBlockGroup1: 43, blocksGroup2: 4, wordsBlockGroup2: 44 , ecWordsBlock: 26},
+    {nversion: 18 ,correction: CorrectionQ, capNum: 948, capAlpha: 574, capByte: 394, capKanji: 243, totalWords: 397, blocksGroup1: 17, wordsBlockGroup1: 22, blocksGroup2: 1, wordsBlockGroup2: 23 , ecWordsBlock: 28},
+    {nversion: 18 ,correction: CorrectionH, capNum: 746, capAlpha: 452, capByte: 310, capKanji: 191, totalWords: 313, blocksGroup1: 2, wordsBlockGroup1: 14, blocksGroup2: 19, wordsBlockGroup2: 15 , ecWordsBlock: 28},
+
+    {nversion: 19 ,correction: CorrectionL, capNum: 1903, capAlpha: 1153, capByte: 792, capKanji: 488, totalWords: 795, blocksGroup1: 3, wordsBlockGroup1: 113, blocksGroup2: 4, wordsBlockGroup2: 114 , ecWordsBlock: 28},
+    {nversion: 19 ,correction: CorrectionM, capNum: 1500, capAlpha: 909, capByte: 624, capKanji: 384, totalWords: 627, blocksGroup1: 3, wordsBlockGroup1: 44, blocksGroup2: 11, wordsBlockGroup2: 45 , ecWordsBlock: 26},
+    {nversion: 19 ,correction: CorrectionQ, capNum: 1063, capAlpha: 644, capByte: 442, capKanji: 272, totalWords: 445, blocksGroup1: 17, wordsBlockGroup1: 21, blocksGroup2: 4, wordsBlockGroup2: 22 , ecWordsBlock: 26},
+    {nversion: 19 ,correction: CorrectionH, capNum: 813, capAlpha: 493, capByte: 338, capKanji: 208, totalWords: 341, blocksGroup1: 9, wordsBlockGroup1: 13, blocksGroup2: 16, wordsBlockGroup2: 14 , ecWordsBlock: 26},
+
+    {nversion: 20 ,correction: CorrectionL, capNum: 2061, capAlpha: 1249, capByte: 858, capKanji: 528, totalWords: 861, blocksGroup1: 3, wordsBlockGroup1: 107, blocksGroup2: 5, wordsBlockGroup2: 108 , ecWordsBlock: 28},
+    {nversion: 20 ,correction: CorrectionM, capNum: 1600, capAlpha: 970, capByte: 666, capKanji: 410, totalWords: 669, blocksGroup1: 3, wordsBlockGroup1: 41, blocksGroup2: 13, wordsBlockGroup2: 42 , ecWordsBlock: 26},
+    {nversion: 20 ,correction: CorrectionQ, capNum: 1159, capAlpha: 702, capByte: 482, capKanji: 297, totalWords: 485, blocksGroup1: 15, wordsBlockGroup1: 24, blocksGroup2: 5, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 20 ,correction: CorrectionH, capNum: 919, capAlpha: 557, capByte: 382, capKanji: 235, totalWords: 385, blocksGroup1: 15, wordsBlockGroup1: 15, blocksGroup2: 10, wordsBlockGroup2: 16 , ecWordsBlock: 28},
+
+    {nversion: 21 ,correction: CorrectionL, capNum: 2232, capAlpha: 1352, capByte: 929, capKanji: 572, totalWords: 932, blocksGroup1: 4, wordsBlockGroup1: 116, blocksGroup2: 4, wordsBlockGroup2: 117 , ecWordsBlock: 28},
+    {nversion: 21 ,correction: CorrectionM, capNum: 1708, capAlpha: 1035, capByte: 711, capKanji: 438, totalWords: 714, blocksGroup1: 17, wordsBlockGroup1: 42, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26},
+    {nversion: 21 ,correction: CorrectionQ, capNum: 1224, capAlpha: 742, capByte: 509, capKanji: 314, totalWords: 512, blocksGroup1: 17, wordsBlockGroup1: 22, blocksGroup2: 6, wordsBlockGroup2: 23 , ecWordsBlock: 28},
+    {nversion: 21 ,correction: CorrectionH, capNum: 969, capAlpha: 587, capByte: 403, capKanji: 248, totalWords: 406, blocksGroup1: 19, wordsBlockGroup1: 16, blocksGroup2: 6, wordsBlockGroup2: 17 , ecWordsBlock: 30},
+
+    {nversion: 22 ,correction: CorrectionL, capNum: 2409, capAlpha: 1460, capByte: 1003, capKanji: 618, totalWords: 1006, blocksGroup1: 2, wordsBlockGroup1: 111, blocksGroup2: 7, wordsBlockGroup2: 112 , ecWordsBlock: 28},
+    {nversion: 22 ,correction: CorrectionM, capNum: 1872, capAlpha: 1134, capByte: 779, capKanji: 480, totalWords: 782, blocksGroup1: 17, wordsBlockGroup1: 46, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 28},
+    {nversion: 22 ,correction: CorrectionQ, capNum: 1358, capAlpha: 823, capByte: 565, capKanji: 348, totalWords: 568, blocksGroup1: 7, wordsBlockGroup1: 24, blocksGroup2: 16, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 22 ,correction: CorrectionH, capNum: 1056, capAlpha: 640, capByte: 439, capKanji: 270, totalWords: 442, blocksGroup1: 34, wordsBlockGroup1: 13, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24},
+
+    {nversion: 23 ,correction: CorrectionL, capNum: 2620, capAlpha: 1588, capByte: 1091, capKanji: 672, totalWords: 1094, blocksGroup1: 4, wordsBlockGroup1: 121, blocksGroup2: 5, wordsBlockGroup2: 122 , ecWordsBlock: 30},
+    {nversion: 23 ,correction: CorrectionM, capNum: 2059, capAlpha: 1248, capByte: 857, capKanji: 528, totalWords: 860, blocksGroup1: 4, wordsBlockGroup1: 47, blocksGroup2: 14, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 23 ,correction: CorrectionQ, capNum: 1468, capAlpha: 890, capByte: 611, capKanji: 376, totalWords: 614, blocksGroup1: 11, wordsBlockGroup1: 24, blocksGroup2: 14, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 23 ,correction: CorrectionH, capNum: 1108, capAlpha: 672, capByte: 461, capKanji: 284, totalWords: 464, blocksGroup1: 16, wordsBlockGroup1: 15, blocksGroup2: 14, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 24 ,correction: CorrectionL, capNum: 2812, capAlpha: 1704, capByte: 1171, capKanji: 721, totalWords: 1174, blocksGroup1: 6, wordsBlockGroup1: 117, blocksGroup2: 4, wordsBlockGroup2: 118 , ecWordsBlock: 30},
+    {nversion: 24 ,correction: CorrectionM, capNum: 2188, capAlpha: 1326, capByte: 911, capKanji: 561, totalWords: 914, blocksGroup1: 6, wordsBlockGroup1: 45, blocksGroup2: 14, wordsBlockGroup2: 46 , ecWordsBlock: 28},
+    {nversion: 24 ,correction: CorrectionQ, capNum: 1588, capAlpha: 963, capByte: 661, capKanji: 407, totalWords: 664, blocksGroup1: 11, wordsBlockGroup1: 24, blocksGroup2: 16, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 24 ,correction: CorrectionH, capNum: 1228, capAlpha: 744, capByte: 511, capKanji: 315, totalWords: 514, blocksGroup1: 30, wordsBlockGroup1: 16, blocksGroup2: 2, wordsBlockGroup2: 17 , ecWordsBlock: 30},
+
+    {nversion: 25 ,correction: CorrectionL, capNum: 3057, capAlpha: 1853, capByte: 1273, capKanji: 784, totalWords: 1276, blocksGroup1: 8, wordsBlockGroup1: 106, blocksGroup2: 4, wordsBlockGroup2: 107 , ecWordsBlock: 26},
+    {nversion: 25 ,correction: CorrectionM, capNum: 2395, capAlpha: 1451, capByte: 997, capKanji: 614, totalWords: 1000, blocksGroup1: 8, wordsBlockGroup1: 47, blocksGroup2: 13, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 25 ,correction: CorrectionQ, capNum: 1718, capAlpha: 1041, capByte: 715, capKanji: 440, totalWords: 718, blocksGroup1: 7, wordsBlockGroup1: 24, blocksGroup2: 22, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 25 ,correction: CorrectionH, capNum: 1286, capAlpha: 779, capByte: 535, capKanji: 330, totalWords: 538, blocksGroup1: 22, wordsBlockGroup1: 15, blocksGroup2: 13, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 26 ,correction: CorrectionL, capNum: 3283, capAlpha: 1990, capByte: 1367, capKanji: 842, totalWords: 1370, blocksGroup1: 10, wordsBlockGroup1: 114, blocksGroup2: 2, wordsBlockGroup2: 115 , ecWordsBlock: 28},
+    {nversion: 26 ,correction: CorrectionM, capNum: 2544, capAlpha: 1542, capByte: 1059, capKanji: 652, totalWords: 1062, blocksGroup1: 19, wordsBlockGroup1: 46, blocksGroup2: 4, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 26 ,correction: CorrectionQ, capNum: 1804, capAlpha: 1094, capByte: 751, capKanji: 462, totalWords: 754, blocksGroup1: 28, wordsBlockGroup1: 22, blocksGroup2: 6, wordsBlockGroup2: 23 , ecWordsBlock: 28},
+    {nversion: 26 ,correction: CorrectionH, capNum: 1425, capAlpha: 864, capByte: 593, capKanji: 365, totalWords: 596, blocksGroup1: 33, wordsBlockGroup1: 16, blocksGroup2: 4, wordsBlockGroup2: 17 , ecWordsBlock: 30},
+
+    {nversion: 27 ,correction: CorrectionL, capNum: 3517, capAlpha: 2132, capByte: 1465, capKanji: 902, totalWords: 1468, blocksGroup1: 8, wordsBlockGroup1: 122, blocksGroup2: 4, wordsBlockGroup2: 123 , ecWordsBlock: 30},
+    {nversion: 27 ,correction: CorrectionM, capNum: 2701, capAlpha: 1637, capByte: 1125, capKanji: 692, totalWords: 1128, blocksGroup1: 22, wordsBlockGroup1: 45, blocksGroup2: 3, wordsBlockGroup2: 46 , ecWordsBlock: 28},
+    {nversion: 27 ,correction: CorrectionQ, capNum: 1933, capAlpha: 1172, capByte: 805, capKanji: 496, totalWords: 808, blocksGroup1: 8, wordsBlockGroup1: 23, blocksGroup2: 26, wordsBlockGroup2: 24 , ecWordsBlock: 30},
+    {nversion: 27 ,correction: CorrectionH, capNum: 1501, capAlpha: 910, capByte: 625, capKanji: 385, totalWords: 628, blocksGroup1: 12, wordsBlockGroup1: 15, blocksGroup2: 28, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 28 ,correction: CorrectionL, capNum: 3669, capAlpha: 2223, capByte: 1528, capKanji: 940, totalWords: 1531, blocksGroup1: 3, wordsBlockGroup1: 117, blocksGroup2: 10, wordsBlockGroup2: 118 , ecWordsBlock: 30},
+    {nversion: 28 ,correction: CorrectionM, capNum: 2857, capAlpha: 1732, capByte: 1190, capKanji: 732, totalWords: 1193, blocksGroup1: 3, wordsBlockGroup1: 45, blocksGroup2: 23, wordsBlockGroup2: 46 , ecWordsBlock: 28},
+    {nversion: 28 ,correction: CorrectionQ, capNum: 2085, capAlpha: 1263, capByte: 868, capKanji: 534, totalWords: 871, blocksGroup1: 4, wordsBlockGroup1: 24, blocksGroup2: 31, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 28 ,correction: CorrectionH, capNum: 1581, capAlpha: 958, capByte: 658, capKanji: 405, totalWords: 661, blocksGroup1: 11, wordsBlockGroup1: 15, blocksGroup2: 31, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 29 ,correction: CorrectionL, capNum: 3909, capAlpha: 2369, capByte: 1628, capKanji: 1002, totalWords: 1631, blocksGroup1: 7, wordsBlockGroup1: 116, blocksGroup2: 7, wordsBlockGroup2: 117 , ecWordsBlock: 30},
+    {nversion: 29 ,correction: CorrectionM, capNum: 3035, capAlpha: 1839, capByte: 1264, capKanji: 778, totalWords: 1267, blocksGroup1: 21, wordsBlockGroup1: 45, blocksGroup2: 7, wordsBlockGroup2: 46 , ecWordsBlock: 28},
+    {nversion: 29 ,correction: CorrectionQ, capNum: 2181, capAlpha: 1322, capByte: 908, capKanji: 559, totalWords: 911, blocksGroup1: 1, wordsBlockGroup1: 23, blocksGroup2: 37, wordsBlockGroup2: 24 , ecWordsBlock: 30},
+    {nversion: 29 ,correction: CorrectionH, capNum: 1677, capAlpha: 1016, capByte: 698, capKanji: 430, totalWords: 701, blocksGroup1: 19, wordsBlockGroup1: 15, blocksGroup2: 26, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 30 ,correction: CorrectionL, capNum: 4158, capAlpha: 2520, capByte: 1732, capKanji: 1066, totalWords: 1735, blocksGroup1: 5, wordsBlockGroup1: 115, blocksGroup2: 10, wordsBlockGroup2: 116 , ecWordsBlock: 30},
+    {nversion: 30 ,correction: CorrectionM, capNum: 3289, capAlpha: 1994, capByte: 1370, capKanji: 843, totalWords: 1373, blocksGroup1: 19, wordsBlockGroup1: 47, blocksGroup2: 10, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 30 ,correction: CorrectionQ, capNum: 2358, capAlpha: 1429, capByte: 982, capKanji: 604, totalWords: 985, blocksGroup1: 15, wordsBlockGroup1: 24, blocksGroup2: 25, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 30 ,correction: CorrectionH, capNum: 1782, capAlpha: 1080, capByte: 742, capKanji: 457, totalWords: 745, blocksGroup1: 23, wordsBlockGroup1: 15, blocksGroup2: 25, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 31 ,correction: CorrectionL, capNum: 4417, capAlpha: 2677, capByte: 1840, capKanji: 1132, totalWords: 1843, blocksGroup1: 13, wordsBlockGroup1: 115, blocksGroup2: 3, wordsBlockGroup2: 116 , ecWordsBlock: 30},
+    {nversion: 31 ,correction: CorrectionM, capNum: 3486, capAlpha: 2113, capByte: 1452, capKanji: 894, totalWords: 1455, blocksGroup1: 2, wordsBlockGroup1: 46, blocksGroup2: 29, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 31 ,correction: CorrectionQ, capNum: 2473, capAlpha: 1499, capByte: 1030, capKanji: 634, totalWords: 1033, blocksGroup1: 42, wordsBlockGroup1: 24, blocksGroup2: 1, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 31 ,correction: CorrectionH, capNum: 1897, capAlpha: 1150, capByte: 790, capKanji: 486, totalWords: 793, blocksGroup1: 23, wordsBlockGroup1: 15, blocksGroup2: 28, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 32 ,correction: CorrectionL, capNum: 4686, capAlpha: 2840, capByte: 1952, capKanji: 1201, totalWords: 1955, blocksGroup1: 17, wordsBlockGroup1: 115, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 30},
+    {nversion: 32 ,correction: CorrectionM, capNum: 3693, capAlpha: 2238, capByte: 1538, capKanji: 947, totalWords: 1541, blocksGroup1: 10, wordsBlockGroup1: 46, blocksGroup2: 23, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 32 ,correction: CorrectionQ, capNum: 2670, capAlpha: 1618, capByte: 1112, capKanji: 684, totalWords: 1115, blocksGroup1: 10, wordsBlockGroup1: 24, blocksGroup2: 35, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 32 ,correction: CorrectionH, capNum: 2022, capAlpha: 1226, capByte: 842, capKanji: 518, totalWords: 845, blocksGroup1: 19, wordsBlockGroup1: 15, blocksGroup2: 35, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 33 ,correction: CorrectionL, capNum: 4965, capAlpha: 3009, capByte: 2068, capKanji: 1273, totalWords: 2071, blocksGroup1: 17, wordsBlockGroup1: 115, blocksGroup2: 1, wordsBlockGroup2: 116 , ecWordsBlock: 30},
+    {nversion: 33 ,correction: CorrectionM, capNum: 3909, capAlpha: 2369, capByte: 1628, capKanji: 1002, totalWords: 1631, blocksGroup1: 14, wordsBlockGroup1: 46, blocksGroup2: 21, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 33 ,correction: CorrectionQ, capNum: 2805, capAlpha: 1700, capByte: 1168, capKanji: 719, totalWords: 1171, blocksGroup1: 29, wordsBlockGroup1: 24, blocksGroup2: 19, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 33 ,correction: CorrectionH, capNum: 2157, capAlpha: 1307, capByte: 898, capKanji: 553, totalWords: 901, blocksGroup1: 11, wordsBlockGroup1: 15, blocksGroup2: 46, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 34 ,correction: CorrectionL, capNum: 5253, capAlpha: 3183, capByte: 2188, capKanji: 1347, totalWords: 2191, blocksGroup1: 13, wordsBlockGroup1: 115, blocksGroup2: 6, wordsBlockGroup2: 116 , ecWordsBlock: 30},
+    {nversion: 34 ,correction: CorrectionM, capNum: 4134, capAlpha: 2506, capByte: 1722, capKanji: 1060, totalWords: 1725, blocksGroup1: 14, wordsBlockGroup1: 46, blocksGroup2: 23, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 34 ,correction: CorrectionQ, capNum: 2949, capAlpha: 1787, capByte: 1228, capKanji: 756, totalWords: 1231, blocksGroup1: 44, wordsBlockGroup1: 24, blocksGroup2: 7, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 34 ,correction: CorrectionH, capNum: 2301, capAlpha: 1394, capByte: 958, capKanji: 590, totalWords: 961, blocksGroup1: 59, wordsBlockGroup1: 16, blocksGroup2: 1, wordsBlockGroup2: 17 , ecWordsBlock: 30},
+
+    {nversion: 35 ,correction: CorrectionL, capNum: 5529, capAlpha: 3351, capByte: 2303, capKanji: 1417, totalWords: 2306, blocksGroup1: 12, wordsBlockGroup1: 121, blocksGroup2: 7, wordsBlockGroup2: 122 , ecWordsBlock: 30},
+    {nversion: 35 ,correction: CorrectionM, capNum: 4343, capAlpha: 2632, capByte: 1809, capKanji: 1113, totalWords: 1812, blocksGroup1: 12, wordsBlockGroup1: 47, blocksGroup2: 26, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 35 ,correction: CorrectionQ, capNum: 3081, capAlpha: 1867, capByte: 1283, capKanji: 790, totalWords: 1286, blocksGroup1: 39, wordsBlockGroup1: 24, blocksGroup2: 14, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 35 ,correction: CorrectionH, capNum: 2361, capAlpha: 1431, capByte: 983, capKanji: 605, totalWords: 986, blocksGroup1: 22, wordsBlockGroup1: 15, blocksGroup2: 41, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 36 ,correction: CorrectionL, capNum: 5836, capAlpha: 3537, capByte: 2431, capKanji: 1496, totalWords: 2434, blocksGroup1: 6, wordsBlockGroup1: 121, blocksGroup2: 14, wordsBlockGroup2: 122 , ecWordsBlock: 30},
+    {nversion: 36 ,correction: CorrectionM, capNum: 4588, capAlpha: 2780, capByte: 1911, capKanji: 1176, totalWords: 1914, blocksGroup1: 6, wordsBlockGroup1: 47, blocksGroup2: 34, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 36 ,correction: CorrectionQ, capNum: 3244, capAlpha: 1966, capByte: 1351, capKanji: 832, totalWords: 1354, blocksGroup1: 46, wordsBlockGroup1: 24, blocksGroup2: 10, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 36 ,correction: CorrectionH, capNum: 2524, capAlpha: 1530, capByte: 1051, capKanji: 647, totalWords: 1054, blocksGroup1: 2, wordsBlockGroup1: 15, blocksGroup2: 64, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 37 ,correction: CorrectionL, capNum: 6153, capAlpha: 3729, capByte: 2563, capKanji: 1577, totalWords: 2566, blocksGroup1: 17, wordsBlockGroup1: 122, blocksGroup2: 4, wordsBlockGroup2: 123 , ecWordsBlock: 30},
+    {nversion: 37 ,correction: CorrectionM, capNum: 4775, capAlpha: 2894, capByte: 1989, capKanji: 1224, totalWords: 1992, blocksGroup1: 29, wordsBlockGroup1: 46, blocksGroup2: 14, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 37 ,correction: CorrectionQ, capNum: 3417, capAlpha: 2071, capByte: 1423, capKanji: 876, totalWords: 1426, blocksGroup1: 49, wordsBlockGroup1: 24, blocksGroup2: 10, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 37 ,correction: CorrectionH, capNum: 2625, capAlpha: 1591, capByte: 1093, capKanji: 673, totalWords: 1096, blocksGroup1: 24, wordsBlockGroup1: 15, blocksGroup2: 46, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 38 ,correction: CorrectionL, capNum: 6479, capAlpha: 3927, capByte: 2699, capKanji: 1661, totalWords: 2702, blocksGroup1: 4, wordsBlockGroup1: 122, blocksGroup2: 18, wordsBlockGroup2: 123 , ecWordsBlock: 30},
+    {nversion: 38 ,correction: CorrectionM, capNum: 5039, capAlpha: 3054, capByte: 2099, capKanji: 1292, totalWords: 2102, blocksGroup1: 13, wordsBlockGroup1: 46, blocksGroup2: 32, wordsBlockGroup2: 47 , ecWordsBlock: 28},
+    {nversion: 38 ,correction: CorrectionQ, capNum: 3599, capAlpha: 2181, capByte: 1499, capKanji: 923, totalWords: 1502, blocksGroup1: 48, wordsBlockGroup1: 24, blocksGroup2: 14, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 38 ,correction: CorrectionH, capNum: 2735, capAlpha: 1658, capByte: 1139, capKanji: 701, totalWords: 1142, blocksGroup1: 42, wordsBlockGroup1: 15, blocksGroup2: 32, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 39 ,correction: CorrectionL, capNum: 6743, capAlpha: 4087, capByte: 2809, capKanji: 1729, totalWords: 2812, blocksGroup1: 20, wordsBlockGroup1: 117, blocksGroup2: 4, wordsBlockGroup2: 118 , ecWordsBlock: 30},
+    {nversion: 39 ,correction: CorrectionM, capNum: 5313, capAlpha: 3220, capByte: 2213, capKanji: 1362, totalWords: 2216, blocksGroup1: 40, wordsBlockGroup1: 47, blocksGroup2: 7, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 39 ,correction: CorrectionQ, capNum: 3791, capAlpha: 2298, capByte: 1579, capKanji: 972, totalWords: 1582, blocksGroup1: 43, wordsBlockGroup1: 24, blocksGroup2: 22, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 39 ,correction: CorrectionH, capNum: 2927, capAlpha: 1774, capByte: 1219, capKanji: 750, totalWords: 1222, blocksGroup1: 10, wordsBlockGroup1: 15, blocksGroup2: 67, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+
+    {nversion: 40 ,correction: CorrectionL, capNum: 7089, capAlpha: 4296, capByte: 2953, capKanji: 1817, totalWords: 2956, blocksGroup1: 19, wordsBlockGroup1: 118, blocksGroup2: 6, wordsBlockGroup2: 119 , ecWordsBlock: 30},
+    {nversion: 40 ,correction: CorrectionM, capNum: 5596, capAlpha: 3391, capByte: 2331, capKanji: 1435, totalWords: 2334, blocksGroup1: 18, wordsBlockGroup1: 47, blocksGroup2: 31, wordsBlockGroup2: 48 , ecWordsBlock: 28},
+    {nversion: 40 ,correction: CorrectionQ, capNum: 3993, capAlpha: 2420, capByte: 1663, capKanji: 1024, totalWords: 1666, blocksGroup1: 34, wordsBlockGroup1: 24, blocksGroup2: 34, wordsBlockGroup2: 25 , ecWordsBlock: 30},
+    {nversion: 40 ,correction: CorrectionH, capNum: 3057, capAlpha: 1852, capByte: 1273, capKanji: 784, totalWords: 1276, blocksGroup1: 20, wordsBlockGroup1: 15, blocksGroup2: 61, wordsBlockGroup2: 16 , ecWordsBlock: 30},
+  }
+}
+
+func determineVersion(input string, correction CorrectionLevel, mode EncodingMode, versions []Version) (Version, error) {
+  if !correction.valid() {
+    return Version{}, ErrInvalidLevel
+  }
+
+  needed := charCount(input, mode)
+  var largest Version
+  for _, v := range versions {
+    if v.correction != correction || !v.supports(mode) {
+      continue
+    }
+    largest = v
+    capacity := 0
+    switch mode {
+    case Numeric:
+      capacity = v.capNum
+      break
+    case Alphanumeric:
+      capacity = v.capAlpha
+      break
+    case Byte:
+      capacity = v.capByte
+      break
+    case Kanji:
+      capacity = v.capKanji
+      break
+      
+    }
+    if capacity >= needed {
+      return v, nil
+    }
+  }
+
+  return Version{}, &DataTooLongError{
+    Level: correction,
+    Modes: []EncodingMode{mode},
+    Bits: segmentsBits([]Segment{{mode: mode, data: input}}, largest),
+    MaxBits: largest.dataBits(),
+  }
+}
+
+func encode(segments []Segment, version Version, correction CorrectionLevel, part StructuredAppend) ([]byte, error) {
+  buffer := part.header()
+
+  for _, segment := range segments {
+    if !version.supports(segment.mode) {
+      return nil, ErrUnsupportedMode
+    }
+    //add mode indicator
+    buffer.AppendBits(version.modeIndicator(segment.mode), version.ModeIndicatorLength())
+
+    // add char count
+    count := charCount(segment.data, segment.mode)
+    buffer.AppendBits(uint32(count), version.CharCountLength(segment.mode))
+
+    //add the encoded data
+    data, err := encodeInMode(segment.data, segment.mode)
+    if err != nil {
+      return nil, err
+    }
+    buffer.AppendBuffer(data)
+  }
+
+  capacity := version.dataBits()
+  if buffer.Len() > capacity {
+    return nil, &DataTooLongError{
+      Level: correction,
+      Version: version.nversion,
+      Micro: version.micro,
+      Modes: segmentModes(segments),
+      Bits: buffer.Len(),
+      MaxBits: capacity,
+    }
+  }
+
+  //terminator of 0s, it's cut short when there's no more room
+  buffer.AppendBits(0, min(version.terminatorLength(), capacity - buffer.Len()))
+  //only M1 and M3 capacities aren't whole bytes, their last 4 bits can't
+  //hold a pad byte and stay 0
+  buffer.AppendBits(0, min((8 - buffer.Len()%8) % 8, capacity - buffer.Len()))
+
+  //fill extra bytes
+  pattern := []byte{0xEC, 0x11}
+  for i := 0; capacity - buffer.Len() >= 8; i++ {
+    buffer.AppendBits(uint32(pattern[i % len(pattern)]), 8)
+  }
+  buffer.AppendBits(0, capacity - buffer.Len())
+
+  return buffer.Bytes(), nil
+}
+
+func encodeInMode(input string, mode EncodingMode) (*BitBuffer, error) {
+  switch mode {
+  case Numeric:
+    return encodeNumeric(input)
+  case Alphanumeric:
+    return encodeAlpha(input)
+  case Byte:
+    return encodeBytes([]byte(input)), nil
+  case Kanji:
+    return encodeKanji(input)
+  }
+  return &BitBuffer{}, nil
+}
+
+// charCount is the value of the character count indicator: the number of
+// bytes in byte mode (so multibyte UTF-8 runes count fully) and the number
+// of characters in every other mode
+func charCount(input string, mode EncodingMode) int {
+  switch mode {
+  case Byte:
+    return len(input)
+  case Kanji:
+    return utf8.RuneCountInString(input)
+  }
+  return len(input)
+}
+
+// encodeBytes encodes each byte as is in 8 bits.
+// Strings are encoded with their UTF-8 bytes, use Latin1Bytes and EncodeBytes
+// to produce ISO-8859-1 data, the default interpretation for byte mode
+func encodeBytes(data []byte) *BitBuffer {
+  buffer := &BitBuffer{}
+  buffer.AppendBytes(data)
+  return buffer
+}
+
+// Latin1Bytes converts the input to ISO-8859-1, it fails if any character
+// is outside of the latin-1 range
+func Latin1Bytes(input string) ([]byte, bool) {
+  result := make([]byte, 0, len(input))
+  for _, char := range input {
+    if char > 0xFF {
+      return nil, false
+    }
+    result = append(result, byte(char))
+  }
+  return result, true
+}
+
+// encodeNumeric encodes the digits in groups of 3 as 10 bit numbers,
+// a final group of 2 digits takes 7 bits and a single digit takes 4
+func encodeNumeric(input string) (*BitBuffer, error) {
+  for i, char := range input {
+    if char < '0' || char > '9' {
+      return nil, &InvalidCharacterError{Char: char, Position: i, Mode: Numeric}
+    }
+  }
+
+  buffer := &BitBuffer{}
+  for i := 0; i < len(input); i += 3 {
+    end := min(i+3, len(input))
+    var value uint32 = 0
+    for _, digit := range input[i:end] {
+      value = value*10 + uint32(digit-'0')
+    }
+    switch end - i {
+    case 3:
+      buffer.AppendBits(value, 10)
+    case 2:
+      buffer.AppendBits(value, 7)
+    case 1:
+      buffer.AppendBits(value, 4)
+    }
+  }
+
+  return buffer, nil
+}
+
+// encodeAlpha encodes pairs of characters as 45*first+second in 11 bits,
+// a final single character takes 6 bits
+func encodeAlpha(input string) (*BitBuffer, error) {
+  table := alphaTranslator()
+  for i, char := range input {
+    if _, ok := table[char]; !ok {
+      return nil, &InvalidCharacterError{Char: char, Position: i, Mode: Alphanumeric}
+    }
+  }
+
+  buffer := &BitBuffer{}
+
+  for i := 0; i < len(input)-1; i+=2 {
+    n1 := table[rune(input[i])]
+    n2 := table[rune(input[i+1])]
+    buffer.AppendBits(uint32((45*n1)+n2), 11)
+  }
+
+  if len(input) % 2 != 0 {
+    c := rune(input[len(input)-1])
+    buffer.AppendBits(uint32(table[c]), 6)
+  }
+
+  return buffer, nil
+}
+
+func alphaTranslator() map[rune]int {
+  return map[rune]int {
+    '0': 0,
+    '1': 1,
+    '2': 2,
+    '3': 3,
+    '4': 4,
+    '5': 5,
+    '6': 6,
+    '7': 7,
+    '8': 8,
+    '9': 9,
+    'A': 10,
+    'B': 11,
+    'C': 12,
+    'D': 13,
+    'E': 14,
+    'F': 15,
+    'G': 16,
+    'H': 17,
+    'I': 18,
+    'J': 19,
+    'K': 20,
+    'L': 21,
+    'M': 22,
+    'N': 23,
+    'O': 24,
+    'P': 25,
+    'Q': 26,
+    'R': 27,
+    'S': 28,
+    'T': 29,
+    'U': 30,
+    'V': 31,
+    'W': 32,
+    'X': 33,
+    'Y': 34,
+    'Z': 35,
+    ' ': 36,
+    '$': 37,
+    '%': 38,
+    '*': 39,
+    '+': 40,
+    '-': 41,
+    '.': 42,
+    '/': 43,
+    ':': 44,
+  }
+}
+
+
+type Block struct {
+  data []byte
+  ec []byte
+}
+
+// errorCorrection splits the data codewords into the blocks described by the
+// version and computes the reed-solomon codewords for each of them
+func errorCorrection(encoded []byte, version Version) []Block {
+  blocks := make([]Block, 0, version.blocksGroup1+version.blocksGroup2)
+
+  offset := 0
+  addGroup := func(numBlocks int, wordsBlock int) {
+    for i := 0; i < numBlocks; i++ {
+      data := encoded[offset:offset+wordsBlock]
+      blocks = append(blocks, Block{data: data, ec: reedSolomon(data, version.ecWordsBlock)})
+      offset += wordsBlock
+    }
+  }
+  addGroup(version.blocksGroup1, version.wordsBlockGroup1)
+  addGroup(version.blocksGroup2, version.wordsBlockGroup2)
+
+  return blocks
+}
+
+
+
+// interleave takes the codewords column by column across all the blocks,
+// first the data and then the error correction, and returns the final bit
+// sequence including the remainder bits.
+// Group 2 blocks can be one codeword longer, those extra words go at the end
+// of the data section
+func interleave(blocks []Block, version Version) *BitBuffer {
+  codewords := []byte{}
+
+  maxData := max(version.wordsBlockGroup1, version.wordsBlockGroup2)
+  for i := 0; i < maxData; i++ {
+    for _, block := range blocks {
+      if i < len(block.data) {
+        codewords = append(codewords, block.data[i])
+      }
+    }
+  }
+  for i := 0; i < version.ecWordsBlock; i++ {
+    for _, block := range blocks {
+      codewords = append(codewords, block.ec[i])
+    }
+  }
+
+  //in M1 and M3 (a single block) only the first 4 bits of the last data
+  //codeword go in the symbol
+  short := version.totalWords*8 - version.dataBits()
+  message := &BitBuffer{}
+  for i, codeword := range codewords {
+    if i == version.totalWords-1 && short > 0 {
+      message.AppendBits(uint32(codeword) >> short, 8-short)
+      continue
+    }
+    message.AppendBits(uint32(codeword), 8)
+  }
+  message.AppendBits(0, version.RemainderBits())
+
+  return message
+}
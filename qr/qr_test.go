@@ -0,0 +1,148 @@
+package qr
+
+import (
+	"bytes"
+	"testing"
+)
+
+// labeledBlocks builds the blocks of the version with every codeword telling
+// where it comes from: block<<5 | index for data and 0x80 | block<<5 | index
+// for error correction
+func labeledBlocks(version Version) []Block {
+  blocks := []Block{}
+  sizes := []int{}
+  for i := 0; i < version.blocksGroup1; i++ {
+    sizes = append(sizes, version.wordsBlockGroup1)
+  }
+  for i := 0; i < version.blocksGroup2; i++ {
+    sizes = append(sizes, version.wordsBlockGroup2)
+  }
+  for b, size := range sizes {
+    block := Block{}
+    for i := 0; i < size; i++ {
+      block.data = append(block.data, byte(b<<5 | i))
+    }
+    for i := 0; i < version.ecWordsBlock; i++ {
+      block.ec = append(block.ec, byte(0x80 | b<<5 | i))
+    }
+    blocks = append(blocks, block)
+  }
+  return blocks
+}
+
+func TestInterleave(t *testing.T) {
+  tests := []struct {
+    name string
+    nversion int
+    level CorrectionLevel
+    codewords []byte
+    remainder int
+  }{
+    {
+      //2 blocks of 15 data words and 2 of 16, the 16th words of group 2
+      //close the data section
+      name: "5-Q", nversion: 5, level: CorrectionQ, remainder: 7,
+      codewords: []byte{
+        0x00, 0x20, 0x40, 0x60, 0x01, 0x21, 0x41, 0x61, 0x02, 0x22, 0x42, 0x62,
+        0x03, 0x23, 0x43, 0x63, 0x04, 0x24, 0x44, 0x64, 0x05, 0x25, 0x45, 0x65,
+        0x06, 0x26, 0x46, 0x66, 0x07, 0x27, 0x47, 0x67, 0x08, 0x28, 0x48, 0x68,
+        0x09, 0x29, 0x49, 0x69, 0x0A, 0x2A, 0x4A, 0x6A, 0x0B, 0x2B, 0x4B, 0x6B,
+        0x0C, 0x2C, 0x4C, 0x6C, 0x0D, 0x2D, 0x4D, 0x6D, 0x0E, 0x2E, 0x4E, 0x6E,
+        0x4F, 0x6F, 0x80, 0xA0, 0xC0, 0xE0, 0x81, 0xA1, 0xC1, 0xE1, 0x82, 0xA2,
+        0xC2, 0xE2, 0x83, 0xA3, 0xC3, 0xE3, 0x84, 0xA4, 0xC4, 0xE4, 0x85, 0xA5,
+        0xC5, 0xE5, 0x86, 0xA6, 0xC6, 0xE6, 0x87, 0xA7, 0xC7, 0xE7, 0x88, 0xA8,
+        0xC8, 0xE8, 0x89, 0xA9, 0xC9, 0xE9, 0x8A, 0xAA, 0xCA, 0xEA, 0x8B, 0xAB,
+        0xCB, 0xEB, 0x8C, 0xAC, 0xCC, 0xEC, 0x8D, 0xAD, 0xCD, 0xED, 0x8E, 0xAE,
+        0xCE, 0xEE, 0x8F, 0xAF, 0xCF, 0xEF, 0x90, 0xB0, 0xD0, 0xF0, 0x91, 0xB1,
+        0xD1, 0xF1,
+      },
+    },
+    {
+      name: "1-M", nversion: 1, level: CorrectionM, remainder: 0,
+      codewords: []byte{
+        0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B,
+        0x0C, 0x0D, 0x0E, 0x0F, 0x80, 0x81, 0x82, 0x83, 0x84, 0x85, 0x86, 0x87,
+        0x88, 0x89,
+      },
+    },
+  }
+
+  for _, test := range tests {
+    t.Run(test.name, func(t *testing.T) {
+      version, err := findVersion(test.nversion, test.level, false)
+      if err != nil {
+        t.Fatal(err)
+      }
+      message := interleave(labeledBlocks(version), version)
+
+      if want := len(test.codewords)*8 + test.remainder; message.Len() != want {
+        t.Fatalf("got %d bits, want %d", message.Len(), want)
+      }
+      got := message.Bytes()[:len(test.codewords)]
+      if !bytes.Equal(got, test.codewords) {
+        t.Errorf("codewords = % X\nwant % X", got, test.codewords)
+      }
+      for i := len(test.codewords)*8; i < message.Len(); i++ {
+        if message.Bit(i) {
+          t.Errorf("remainder bit %d is 1", i)
+        }
+      }
+    })
+  }
+}
+
+// bitString writes the bits of the buffer as 0s and 1s
+func bitString(buffer *BitBuffer) string {
+  bits := make([]byte, buffer.Len())
+  for i := range bits {
+    bits[i] = '0'
+    if buffer.Bit(i) {
+      bits[i] = '1'
+    }
+  }
+  return string(bits)
+}
+
+func TestEncodeNumeric(t *testing.T) {
+  tests := []struct {
+    input string
+    bits string
+  }{
+    {"", ""},
+    {"8", "1000"},
+    {"42", "0101010"},
+    {"012", "0000001100"},
+    {"0123", "0000001100" + "0011"},
+    {"01234", "0000001100" + "0100010"},
+    {"012345", "0000001100" + "0101011001"},
+    {"01234567", "0000001100" + "0101011001" + "1000011"},
+    //groups keep their leading zeros in full width
+    {"007", "0000000111"},
+    {"0001", "0000000000" + "0001"},
+    {"00", "0000000"},
+    {"0", "0000"},
+    {"999000", "1111100111" + "0000000000"},
+  }
+
+  for _, test := range tests {
+    buffer, err := encodeNumeric(test.input)
+    if err != nil {
+      t.Errorf("encodeNumeric(%q): %v", test.input, err)
+      continue
+    }
+    if got := bitString(buffer); got != test.bits {
+      t.Errorf("encodeNumeric(%q) = %s, want %s", test.input, got, test.bits)
+    }
+  }
+}
+
+func TestEncodeNumericInvalid(t *testing.T) {
+  _, err := encodeNumeric("12a4")
+  invalid, ok := err.(*InvalidCharacterError)
+  if !ok {
+    t.Fatalf("got %v, want an InvalidCharacterError", err)
+  }
+  if invalid.Char != 'a' || invalid.Position != 2 || invalid.Mode != Numeric {
+    t.Errorf("got %+v", invalid)
+  }
+}
@@ -0,0 +1,68 @@
+package qr
+
+// galois field GF(256) with the QR primitive polynomial x^8 + x^4 + x^3 + x^2 + 1
+const gfPrimitive = 0x11D
+
+var gfExp, gfLog = gfTables()
+
+// gfTables builds the antilog (exp) and log tables for GF(256).
+// exp is doubled in size so products can index it without a modulo
+func gfTables() ([512]byte, [256]byte) {
+  var exp [512]byte
+  var log [256]byte
+
+  x := 1
+  for i := 0; i < 255; i++ {
+    exp[i] = byte(x)
+    log[x] = byte(i)
+    x <<= 1
+    if x & 0x100 != 0 {
+      x ^= gfPrimitive
+    }
+  }
+  for i := 255; i < len(exp); i++ {
+    exp[i] = exp[i-255]
+  }
+
+  return exp, log
+}
+
+func gfMul(a, b byte) byte {
+  if a == 0 || b == 0 {
+    return 0
+  }
+  return gfExp[int(gfLog[a]) + int(gfLog[b])]
+}
+
+// generatorPoly returns the coefficients of (x - a^0)(x - a^1)...(x - a^(degree-1)),
+// highest power first. The leading coefficient is always 1
+func generatorPoly(degree int) []byte {
+  poly := []byte{1}
+  for i := 0; i < degree; i++ {
+    next := make([]byte, len(poly)+1)
+    for j, coef := range poly {
+      next[j] ^= coef
+      next[j+1] ^= gfMul(coef, gfExp[i])
+    }
+    poly = next
+  }
+  return poly
+}
+
+// reedSolomon computes the ecWords error correction codewords for data
+// (the remainder of data(x) * x^ecWords divided by the generator polynomial)
+func reedSolomon(data []byte, ecWords int) []byte {
+  gen := generatorPoly(ecWords)
+  remainder := make([]byte, ecWords)
+
+  for _, b := range data {
+    factor := b ^ remainder[0]
+    copy(remainder, remainder[1:])
+    remainder[len(remainder)-1] = 0
+    for i := range remainder {
+      remainder[i] ^= gfMul(gen[i+1], factor)
+    }
+  }
+
+  return remainder
+}
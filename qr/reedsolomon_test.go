@@ -0,0 +1,41 @@
+package qr
+
+import (
+	"bytes"
+	"testing"
+)
+
+// the 1-M "01234567" example of ISO/IEC 18004 annex I
+func TestReedSolomonISOExample(t *testing.T) {
+  data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+  want := []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}
+
+  got := reedSolomon(data, len(want))
+  if !bytes.Equal(got, want) {
+    t.Errorf("reedSolomon = % X, want % X", got, want)
+  }
+}
+
+func TestErrorCorrectionISOExample(t *testing.T) {
+  version, err := findVersion(1, CorrectionM, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  encoded, err := encode([]Segment{{mode: Numeric, data: "01234567"}}, version, CorrectionM, StructuredAppend{})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  blocks := errorCorrection(encoded, version)
+  if len(blocks) != 1 {
+    t.Fatalf("got %d blocks, want 1", len(blocks))
+  }
+  wantData := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+  wantEC := []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}
+  if !bytes.Equal(blocks[0].data, wantData) {
+    t.Errorf("data = % X, want % X", blocks[0].data, wantData)
+  }
+  if !bytes.Equal(blocks[0].ec, wantEC) {
+    t.Errorf("ec = % X, want % X", blocks[0].ec, wantEC)
+  }
+}
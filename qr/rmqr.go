@@ -0,0 +1,231 @@
+package qr
+
+import (
+	"slices"
+)
+
+// listRectVersions is the version table of rectangular Micro QR (rMQR)
+// symbols, ISO/IEC 23941. They only have levels M and H, and their count
+// widths and remainder bits change with each size
+func listRectVersions() []Version {
+  return []Version{
+    {nversion: 0, rmqr: true, height: 7, width: 43, correction: CorrectionM, capNum: 12, capAlpha: 7, capByte: 5, capKanji: 3, totalWords: 6, blocksGroup1: 1, wordsBlockGroup1: 6, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 7, countLengths: [4]int{4, 3, 3, 2}, remainder: 0},
+    {nversion: 0, rmqr: true, height: 7, width: 43, correction: CorrectionH, capNum: 5, capAlpha: 3, capByte: 2, capKanji: 1, totalWords: 3, blocksGroup1: 1, wordsBlockGroup1: 3, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 10, countLengths: [4]int{4, 3, 3, 2}, remainder: 0},
+
+    {nversion: 1, rmqr: true, height: 7, width: 59, correction: CorrectionM, capNum: 26, capAlpha: 16, capByte: 11, capKanji: 6, totalWords: 12, blocksGroup1: 1, wordsBlockGroup1: 12, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 9, countLengths: [4]int{5, 5, 4, 3}, remainder: 3},
+    {nversion: 1, rmqr: true, height: 7, width: 59, correction: CorrectionH, capNum: 14, capAlpha: 8, capByte: 6, capKanji: 3, totalWords: 7, blocksGroup1: 1, wordsBlockGroup1: 7, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 14, countLengths: [4]int{5, 5, 4, 3}, remainder: 3},
+
+    {nversion: 2, rmqr: true, height: 7, width: 77, correction: CorrectionM, capNum: 45, capAlpha: 27, capByte: 19, capKanji: 11, totalWords: 20, blocksGroup1: 1, wordsBlockGroup1: 20, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 12, countLengths: [4]int{6, 5, 5, 4}, remainder: 5},
+    {nversion: 2, rmqr: true, height: 7, width: 77, correction: CorrectionH, capNum: 21, capAlpha: 13, capByte: 9, capKanji: 5, totalWords: 10, blocksGroup1: 1, wordsBlockGroup1: 10, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{6, 5, 5, 4}, remainder: 5},
+
+    {nversion: 3, rmqr: true, height: 7, width: 99, correction: CorrectionM, capNum: 64, capAlpha: 39, capByte: 27, capKanji: 16, totalWords: 28, blocksGroup1: 1, wordsBlockGroup1: 28, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 16, countLengths: [4]int{7, 6, 5, 5}, remainder: 6},
+    {nversion: 3, rmqr: true, height: 7, width: 99, correction: CorrectionH, capNum: 30, capAlpha: 18, capByte: 13, capKanji: 8, totalWords: 14, blocksGroup1: 1, wordsBlockGroup1: 14, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 30, countLengths: [4]int{7, 6, 5, 5}, remainder: 6},
+
+    {nversion: 4, rmqr: true, height: 7, width: 139, correction: CorrectionM, capNum: 102, capAlpha: 62, capByte: 42, capKanji: 26, totalWords: 44, blocksGroup1: 1, wordsBlockGroup1: 44, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24, countLengths: [4]int{7, 6, 6, 5}, remainder: 1},
+    {nversion: 4, rmqr: true, height: 7, width: 139, correction: CorrectionH, capNum: 54, capAlpha: 33, capByte: 22, capKanji: 14, totalWords: 24, blocksGroup1: 2, wordsBlockGroup1: 12, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{7, 6, 6, 5}, remainder: 1},
+
+    {nversion: 5, rmqr: true, height: 9, width: 43, correction: CorrectionM, capNum: 26, capAlpha: 16, capByte: 11, capKanji: 6, totalWords: 12, blocksGroup1: 1, wordsBlockGroup1: 12, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 9, countLengths: [4]int{5, 5, 4, 3}, remainder: 2},
+    {nversion: 5, rmqr: true, height: 9, width: 43, correction: CorrectionH, capNum: 14, capAlpha: 8, capByte: 6, capKanji: 3, totalWords: 7, blocksGroup1: 1, wordsBlockGroup1: 7, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 14, countLengths: [4]int{5, 5, 4, 3}, remainder: 2},
+
+    {nversion: 6, rmqr: true, height: 9, width: 59, correction: CorrectionM, capNum: 47, capAlpha: 29, capByte: 20, capKanji: 12, totalWords: 21, blocksGroup1: 1, wordsBlockGroup1: 21, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 12, countLengths: [4]int{6, 5, 5, 4}, remainder: 3},
+    {nversion: 6, rmqr: true, height: 9, width: 59, correction: CorrectionH, capNum: 23, capAlpha: 14, capByte: 10, capKanji: 6, totalWords: 11, blocksGroup1: 1, wordsBlockGroup1: 11, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{6, 5, 5, 4}, remainder: 3},
+
+    {nversion: 7, rmqr: true, height: 9, width: 77, correction: CorrectionM, capNum: 71, capAlpha: 43, capByte: 30, capKanji: 18, totalWords: 31, blocksGroup1: 1, wordsBlockGroup1: 31, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 18, countLengths: [4]int{7, 6, 5, 5}, remainder: 1},
+    {nversion: 7, rmqr: true, height: 9, width: 77, correction: CorrectionH, capNum: 37, capAlpha: 23, capByte: 16, capKanji: 9, totalWords: 17, blocksGroup1: 1, wordsBlockGroup1: 8, blocksGroup2: 1, wordsBlockGroup2: 9, ecWordsBlock: 16, countLengths: [4]int{7, 6, 5, 5}, remainder: 1},
+
+    {nversion: 8, rmqr: true, height: 9, width: 99, correction: CorrectionM, capNum: 97, capAlpha: 59, capByte: 40, capKanji: 25, totalWords: 42, blocksGroup1: 1, wordsBlockGroup1: 42, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24, countLengths: [4]int{7, 6, 6, 5}, remainder: 4},
+    {nversion: 8, rmqr: true, height: 9, width: 99, correction: CorrectionH, capNum: 49, capAlpha: 30, capByte: 20, capKanji: 12, totalWords: 22, blocksGroup1: 2, wordsBlockGroup1: 11, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{7, 6, 6, 5}, remainder: 4},
+
+    {nversion: 9, rmqr: true, height: 9, width: 139, correction: CorrectionM, capNum: 147, capAlpha: 89, capByte: 61, capKanji: 38, totalWords: 63, blocksGroup1: 1, wordsBlockGroup1: 31, blocksGroup2: 1, wordsBlockGroup2: 32, ecWordsBlock: 18, countLengths: [4]int{8, 7, 6, 6}, remainder: 5},
+    {nversion: 9, rmqr: true, height: 9, width: 139, correction: CorrectionH, capNum: 75, capAlpha: 46, capByte: 31, capKanji: 19, totalWords: 33, blocksGroup1: 3, wordsBlockGroup1: 11, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{8, 7, 6, 6}, remainder: 5},
+
+    {nversion: 10, rmqr: true, height: 11, width: 27, correction: CorrectionM, capNum: 14, capAlpha: 8, capByte: 6, capKanji: 3, totalWords: 7, blocksGroup1: 1, wordsBlockGroup1: 7, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 8, countLengths: [4]int{4, 4, 3, 2}, remainder: 2},
+    {nversion: 10, rmqr: true, height: 11, width: 27, correction: CorrectionH, capNum: 9, capAlpha: 6, capByte: 4, capKanji: 2, totalWords: 5, blocksGroup1: 1, wordsBlockGroup1: 5, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 10, countLengths: [4]int{4, 4, 3, 2}, remainder: 2},
+
+    {nversion: 11, rmqr: true, height: 11, width: 43, correction: CorrectionM, capNum: 42, capAlpha: 26, capByte: 18, capKanji: 11, totalWords: 19, blocksGroup1: 1, wordsBlockGroup1: 19, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 12, countLengths: [4]int{6, 5, 5, 4}, remainder: 1},
+    {nversion: 11, rmqr: true, height: 11, width: 43, correction: CorrectionH, capNum: 23, capAlpha: 14, capByte: 10, capKanji: 6, totalWords: 11, blocksGroup1: 1, wordsBlockGroup1: 11, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 20, countLengths: [4]int{6, 5, 5, 4}, remainder: 1},
+
+    {nversion: 12, rmqr: true, height: 11, width: 59, correction: CorrectionM, capNum: 71, capAlpha: 43, capByte: 30, capKanji: 18, totalWords: 31, blocksGroup1: 1, wordsBlockGroup1: 31, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 16, countLengths: [4]int{7, 6, 5, 5}, remainder: 0},
+    {nversion: 12, rmqr: true, height: 11, width: 59, correction: CorrectionH, capNum: 33, capAlpha: 20, capByte: 14, capKanji: 8, totalWords: 15, blocksGroup1: 1, wordsBlockGroup1: 7, blocksGroup2: 1, wordsBlockGroup2: 8, ecWordsBlock: 16, countLengths: [4]int{7, 6, 5, 5}, remainder: 0},
+
+    {nversion: 13, rmqr: true, height: 11, width: 77, correction: CorrectionM, capNum: 100, capAlpha: 60, capByte: 41, capKanji: 25, totalWords: 43, blocksGroup1: 1, wordsBlockGroup1: 43, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24, countLengths: [4]int{7, 6, 6, 5}, remainder: 2},
+    {nversion: 13, rmqr: true, height: 11, width: 77, correction: CorrectionH, capNum: 52, capAlpha: 31, capByte: 21, capKanji: 13, totalWords: 23, blocksGroup1: 1, wordsBlockGroup1: 11, blocksGroup2: 1, wordsBlockGroup2: 12, ecWordsBlock: 22, countLengths: [4]int{7, 6, 6, 5}, remainder: 2},
+
+    {nversion: 14, rmqr: true, height: 11, width: 99, correction: CorrectionM, capNum: 133, capAlpha: 81, capByte: 55, capKanji: 34, totalWords: 57, blocksGroup1: 1, wordsBlockGroup1: 28, blocksGroup2: 1, wordsBlockGroup2: 29, ecWordsBlock: 16, countLengths: [4]int{8, 7, 6, 6}, remainder: 7},
+    {nversion: 14, rmqr: true, height: 11, width: 99, correction: CorrectionH, capNum: 66, capAlpha: 40, capByte: 27, capKanji: 17, totalWords: 29, blocksGroup1: 1, wordsBlockGroup1: 14, blocksGroup2: 1, wordsBlockGroup2: 15, ecWordsBlock: 30, countLengths: [4]int{8, 7, 6, 6}, remainder: 7},
+
+    {nversion: 15, rmqr: true, height: 11, width: 139, correction: CorrectionM, capNum: 198, capAlpha: 120, capByte: 82, capKanji: 51, totalWords: 84, blocksGroup1: 2, wordsBlockGroup1: 42, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24, countLengths: [4]int{8, 7, 7, 6}, remainder: 6},
+    {nversion: 15, rmqr: true, height: 11, width: 139, correction: CorrectionH, capNum: 97, capAlpha: 59, capByte: 40, capKanji: 25, totalWords: 42, blocksGroup1: 3, wordsBlockGroup1: 14, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 30, countLengths: [4]int{8, 7, 7, 6}, remainder: 6},
+
+    {nversion: 16, rmqr: true, height: 13, width: 27, correction: CorrectionM, capNum: 26, capAlpha: 16, capByte: 11, capKanji: 6, totalWords: 12, blocksGroup1: 1, wordsBlockGroup1: 12, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 9, countLengths: [4]int{5, 5, 4, 3}, remainder: 4},
+    {nversion: 16, rmqr: true, height: 13, width: 27, correction: CorrectionH, capNum: 14, capAlpha: 8, capByte: 6, capKanji: 3, totalWords: 7, blocksGroup1: 1, wordsBlockGroup1: 7, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 14, countLengths: [4]int{5, 5, 4, 3}, remainder: 4},
+
+    {nversion: 17, rmqr: true, height: 13, width: 43, correction: CorrectionM, capNum: 62, capAlpha: 37, capByte: 26, capKanji: 16, totalWords: 27, blocksGroup1: 1, wordsBlockGroup1: 27, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 14, countLengths: [4]int{6, 6, 5, 5}, remainder: 1},
+    {nversion: 17, rmqr: true, height: 13, width: 43, correction: CorrectionH, capNum: 28, capAlpha: 17, capByte: 12, capKanji: 7, totalWords: 13, blocksGroup1: 1, wordsBlockGroup1: 13, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 28, countLengths: [4]int{6, 6, 5, 5}, remainder: 1},
+
+    {nversion: 18, rmqr: true, height: 13, width: 59, correction: CorrectionM, capNum: 88, capAlpha: 53, capByte: 36, capKanji: 22, totalWords: 38, blocksGroup1: 1, wordsBlockGroup1: 38, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{7, 6, 6, 5}, remainder: 6},
+    {nversion: 18, rmqr: true, height: 13, width: 59, correction: CorrectionH, capNum: 45, capAlpha: 27, capByte: 18, capKanji: 11, totalWords: 20, blocksGroup1: 2, wordsBlockGroup1: 10, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 20, countLengths: [4]int{7, 6, 6, 5}, remainder: 6},
+
+    {nversion: 19, rmqr: true, height: 13, width: 77, correction: CorrectionM, capNum: 124, capAlpha: 75, capByte: 51, capKanji: 31, totalWords: 53, blocksGroup1: 1, wordsBlockGroup1: 26, blocksGroup2: 1, wordsBlockGroup2: 27, ecWordsBlock: 16, countLengths: [4]int{7, 7, 6, 6}, remainder: 4},
+    {nversion: 19, rmqr: true, height: 13, width: 77, correction: CorrectionH, capNum: 66, capAlpha: 40, capByte: 27, capKanji: 17, totalWords: 29, blocksGroup1: 1, wordsBlockGroup1: 14, blocksGroup2: 1, wordsBlockGroup2: 15, ecWordsBlock: 28, countLengths: [4]int{7, 7, 6, 6}, remainder: 4},
+
+    {nversion: 20, rmqr: true, height: 13, width: 99, correction: CorrectionM, capNum: 171, capAlpha: 104, capByte: 71, capKanji: 44, totalWords: 73, blocksGroup1: 1, wordsBlockGroup1: 36, blocksGroup2: 1, wordsBlockGroup2: 37, ecWordsBlock: 20, countLengths: [4]int{8, 7, 7, 6}, remainder: 3},
+    {nversion: 20, rmqr: true, height: 13, width: 99, correction: CorrectionH, capNum: 80, capAlpha: 49, capByte: 33, capKanji: 20, totalWords: 35, blocksGroup1: 1, wordsBlockGroup1: 11, blocksGroup2: 2, wordsBlockGroup2: 12, ecWordsBlock: 26, countLengths: [4]int{8, 7, 7, 6}, remainder: 3},
+
+    {nversion: 21, rmqr: true, height: 13, width: 139, correction: CorrectionM, capNum: 251, capAlpha: 152, capByte: 104, capKanji: 64, totalWords: 106, blocksGroup1: 2, wordsBlockGroup1: 35, blocksGroup2: 1, wordsBlockGroup2: 36, ecWordsBlock: 20, countLengths: [4]int{8, 8, 7, 7}, remainder: 0},
+    {nversion: 21, rmqr: true, height: 13, width: 139, correction: CorrectionH, capNum: 126, capAlpha: 76, capByte: 52, capKanji: 32, totalWords: 54, blocksGroup1: 2, wordsBlockGroup1: 13, blocksGroup2: 2, wordsBlockGroup2: 14, ecWordsBlock: 28, countLengths: [4]int{8, 8, 7, 7}, remainder: 0},
+
+    {nversion: 22, rmqr: true, height: 15, width: 43, correction: CorrectionM, capNum: 76, capAlpha: 46, capByte: 31, capKanji: 19, totalWords: 33, blocksGroup1: 1, wordsBlockGroup1: 33, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 18, countLengths: [4]int{7, 6, 6, 5}, remainder: 1},
+    {nversion: 22, rmqr: true, height: 15, width: 43, correction: CorrectionH, capNum: 33, capAlpha: 20, capByte: 13, capKanji: 8, totalWords: 15, blocksGroup1: 1, wordsBlockGroup1: 7, blocksGroup2: 1, wordsBlockGroup2: 8, ecWordsBlock: 18, countLengths: [4]int{7, 6, 6, 5}, remainder: 1},
+
+    {nversion: 23, rmqr: true, height: 15, width: 59, correction: CorrectionM, capNum: 112, capAlpha: 68, capByte: 46, capKanji: 28, totalWords: 48, blocksGroup1: 1, wordsBlockGroup1: 48, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26, countLengths: [4]int{7, 7, 6, 5}, remainder: 4},
+    {nversion: 23, rmqr: true, height: 15, width: 59, correction: CorrectionH, capNum: 59, capAlpha: 36, capByte: 24, capKanji: 15, totalWords: 26, blocksGroup1: 2, wordsBlockGroup1: 13, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24, countLengths: [4]int{7, 7, 6, 5}, remainder: 4},
+
+    {nversion: 24, rmqr: true, height: 15, width: 77, correction: CorrectionM, capNum: 157, capAlpha: 95, capByte: 65, capKanji: 40, totalWords: 67, blocksGroup1: 1, wordsBlockGroup1: 33, blocksGroup2: 1, wordsBlockGroup2: 34, ecWordsBlock: 18, countLengths: [4]int{8, 7, 7, 6}, remainder: 6},
+    {nversion: 24, rmqr: true, height: 15, width: 77, correction: CorrectionH, capNum: 71, capAlpha: 43, capByte: 29, capKanji: 18, totalWords: 31, blocksGroup1: 2, wordsBlockGroup1: 10, blocksGroup2: 1, wordsBlockGroup2: 11, ecWordsBlock: 24, countLengths: [4]int{8, 7, 7, 6}, remainder: 6},
+
+    {nversion: 25, rmqr: true, height: 15, width: 99, correction: CorrectionM, capNum: 207, capAlpha: 126, capByte: 86, capKanji: 53, totalWords: 88, blocksGroup1: 2, wordsBlockGroup1: 44, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 24, countLengths: [4]int{8, 7, 7, 6}, remainder: 7},
+    {nversion: 25, rmqr: true, height: 15, width: 99, correction: CorrectionH, capNum: 111, capAlpha: 68, capByte: 46, capKanji: 28, totalWords: 48, blocksGroup1: 4, wordsBlockGroup1: 12, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{8, 7, 7, 6}, remainder: 7},
+
+    {nversion: 26, rmqr: true, height: 15, width: 139, correction: CorrectionM, capNum: 301, capAlpha: 182, capByte: 125, capKanji: 77, totalWords: 127, blocksGroup1: 2, wordsBlockGroup1: 42, blocksGroup2: 1, wordsBlockGroup2: 43, ecWordsBlock: 24, countLengths: [4]int{9, 8, 7, 7}, remainder: 2},
+    {nversion: 26, rmqr: true, height: 15, width: 139, correction: CorrectionH, capNum: 162, capAlpha: 98, capByte: 67, capKanji: 41, totalWords: 69, blocksGroup1: 1, wordsBlockGroup1: 13, blocksGroup2: 4, wordsBlockGroup2: 14, ecWordsBlock: 26, countLengths: [4]int{9, 8, 7, 7}, remainder: 2},
+
+    {nversion: 27, rmqr: true, height: 17, width: 43, correction: CorrectionM, capNum: 85, capAlpha: 52, capByte: 35, capKanji: 22, totalWords: 37, blocksGroup1: 1, wordsBlockGroup1: 18, blocksGroup2: 1, wordsBlockGroup2: 19, ecWordsBlock: 12, countLengths: [4]int{7, 6, 6, 5}, remainder: 1},
+    {nversion: 27, rmqr: true, height: 17, width: 43, correction: CorrectionH, capNum: 47, capAlpha: 28, capByte: 19, capKanji: 12, totalWords: 21, blocksGroup1: 1, wordsBlockGroup1: 10, blocksGroup2: 1, wordsBlockGroup2: 11, ecWordsBlock: 20, countLengths: [4]int{7, 6, 6, 5}, remainder: 1},
+
+    {nversion: 28, rmqr: true, height: 17, width: 59, correction: CorrectionM, capNum: 131, capAlpha: 79, capByte: 54, capKanji: 33, totalWords: 56, blocksGroup1: 2, wordsBlockGroup1: 28, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 16, countLengths: [4]int{8, 7, 6, 6}, remainder: 2},
+    {nversion: 28, rmqr: true, height: 17, width: 59, correction: CorrectionH, capNum: 63, capAlpha: 38, capByte: 26, capKanji: 16, totalWords: 28, blocksGroup1: 2, wordsBlockGroup1: 14, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 30, countLengths: [4]int{8, 7, 6, 6}, remainder: 2},
+
+    {nversion: 29, rmqr: true, height: 17, width: 77, correction: CorrectionM, capNum: 183, capAlpha: 111, capByte: 76, capKanji: 47, totalWords: 78, blocksGroup1: 2, wordsBlockGroup1: 39, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 22, countLengths: [4]int{8, 7, 7, 6}, remainder: 0},
+    {nversion: 29, rmqr: true, height: 17, width: 77, correction: CorrectionH, capNum: 87, capAlpha: 53, capByte: 36, capKanji: 22, totalWords: 38, blocksGroup1: 1, wordsBlockGroup1: 12, blocksGroup2: 2, wordsBlockGroup2: 13, ecWordsBlock: 28, countLengths: [4]int{8, 7, 7, 6}, remainder: 0},
+
+    {nversion: 30, rmqr: true, height: 17, width: 99, correction: CorrectionM, capNum: 236, capAlpha: 143, capByte: 98, capKanji: 60, totalWords: 100, blocksGroup1: 2, wordsBlockGroup1: 33, blocksGroup2: 1, wordsBlockGroup2: 34, ecWordsBlock: 20, countLengths: [4]int{8, 8, 7, 6}, remainder: 3},
+    {nversion: 30, rmqr: true, height: 17, width: 99, correction: CorrectionH, capNum: 131, capAlpha: 79, capByte: 54, capKanji: 33, totalWords: 56, blocksGroup1: 4, wordsBlockGroup1: 14, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 26, countLengths: [4]int{8, 8, 7, 6}, remainder: 3},
+
+    {nversion: 31, rmqr: true, height: 17, width: 139, correction: CorrectionM, capNum: 361, capAlpha: 219, capByte: 150, capKanji: 92, totalWords: 152, blocksGroup1: 4, wordsBlockGroup1: 38, blocksGroup2: 0, wordsBlockGroup2: 0, ecWordsBlock: 20, countLengths: [4]int{9, 8, 8, 7}, remainder: 4},
+    {nversion: 31, rmqr: true, height: 17, width: 139, correction: CorrectionH, capNum: 178, capAlpha: 108, capByte: 74, capKanji: 46, totalWords: 76, blocksGroup1: 2, wordsBlockGroup1: 12, blocksGroup2: 4, wordsBlockGroup2: 13, ecWordsBlock: 26, countLengths: [4]int{9, 8, 8, 7}, remainder: 4},
+  }
+}
+
+// rectVersions lists the rMQR versions no taller than maxHeight, from the
+// smallest area, the shortest first on ties
+func rectVersions(maxHeight int) []Version {
+  versions := []Version{}
+  for _, v := range listRectVersions() {
+    if v.height <= maxHeight {
+      versions = append(versions, v)
+    }
+  }
+  slices.SortStableFunc(versions, func(a, b Version) int {
+    if a.width*a.height != b.width*b.height {
+      return a.width*a.height - b.width*b.height
+    }
+    return a.height - b.height
+  })
+  return versions
+}
+
+// rectAlignmentColumns are the columns of the alignment patterns, each
+// one has a 3x3 pattern on the top and bottom edges joined by a timing line
+func rectAlignmentColumns(width int) []int {
+  switch width {
+  case 43:
+    return []int{21}
+  case 59:
+    return []int{19, 39}
+  case 77:
+    return []int{25, 51}
+  case 99:
+    return []int{23, 49, 75}
+  case 139:
+    return []int{27, 55, 83, 111}
+  }
+  return []int{}
+}
+
+// buildRectFunctionPatterns creates the matrix of an rMQR symbol: timing
+// patterns on every edge, the finder in the top left corner, the 5x5 sub
+// finder in the bottom right one, corner patterns in the other two, the
+// alignment patterns and both format areas reserved
+func buildRectFunctionPatterns(version Version) *Matrix {
+  m := newMatrix(version.width, version.height)
+  m.rect = true
+  w, h := version.width, version.height
+
+  //timing patterns first, the rest overwrite them
+  for col := 0; col < w; col++ {
+    m.setFunction(0, col, col%2 == 0)
+    m.setFunction(h-1, col, col%2 == 0)
+  }
+  for row := 0; row < h; row++ {
+    m.setFunction(row, 0, row%2 == 0)
+    m.setFunction(row, w-1, row%2 == 0)
+  }
+  for _, col := range rectAlignmentColumns(w) {
+    for row := 0; row < h; row++ {
+      m.setFunction(row, col, row%2 == 0)
+    }
+    for _, top := range []int{0, h-3} {
+      for r := 0; r < 3; r++ {
+        for c := -1; c <= 1; c++ {
+          m.setFunction(top+r, col+c, r != 1 || c != 0)
+        }
+      }
+    }
+  }
+
+  m.placeFinder(0, 0)
+
+  for r := 0; r < 5; r++ {
+    for c := 0; c < 5; c++ {
+      m.setFunction(h-5+r, w-5+c, max(abs(r-2), abs(c-2)) != 1)
+    }
+  }
+
+  //corner patterns
+  m.setFunction(0, w-2, true)
+  m.setFunction(1, w-2, false)
+  m.setFunction(1, w-1, true)
+  m.setFunction(h-1, 1, true)
+  if h >= 11 {
+    m.setFunction(h-2, 0, true)
+    m.setFunction(h-2, 1, false)
+  }
+
+  placeRectFormat(m, version)
+
+  return m
+}
+
+// rectFormatBits computes the 18 bit format information of rMQR symbols:
+// the level (0 for M, 1 for H) and the 5 bit version index, followed by
+// their BCH(18,6) code. Each copy is XORed with its own pattern
+func rectFormatBits(version Version) uint32 {
+  var data uint32 = uint32(version.nversion)
+  if version.correction == CorrectionH {
+    data |= 1 << 5
+  }
+  return data << 12 | bchRemainder(data, 0b1111100100101)
+}
+
+// placeRectFormat writes the format information right of the finder's
+// separator and left of the sub finder, in columns of 5 from bit 0.
+// There is a single mask, so it can go in with the function patterns
+func placeRectFormat(m *Matrix, version Version) {
+  bits := rectFormatBits(version)
+  finderSide := bits ^ 0b011111101010110010
+  subFinderSide := bits ^ 0b100000101001111011
+  bit := func(value uint32, i int) bool {
+    return (value >> i) & 1 == 1
+  }
+
+  for i := 0; i < 18; i++ {
+    m.setFunction(1 + i%5, 8 + i/5, bit(finderSide, i))
+  }
+  for i := 0; i < 15; i++ {
+    m.setFunction(m.height-6 + i%5, m.width-8 + i/5, bit(subFinderSide, i))
+  }
+  for i := 15; i < 18; i++ {
+    m.setFunction(m.height-6, m.width-20 + i, bit(subFinderSide, i))
+  }
+}
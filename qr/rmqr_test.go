@@ -0,0 +1,145 @@
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// goldenR7x43 is "123456" in R7x43-M: data codewords 2C 3D B9 00 EC 11
+// (numeric mode 001, count 0110, 123 and 456 in 10 bits, terminator 000,
+// padding) and error correction BF 8F E9 F4 9B FE 1D, with the mask of
+// (row/2 + col/3) % 2 == 0
+const goldenR7x43 = `#######.#.#.#.#.#.#.###.#.#.#.#.#.#.#.#.###
+#.....#..#.#.....#..#.##....##..##.##...#.#
+#.###.#.#.###...#######.##...##.#.#########
+#.###.#..##...#..#.##.###..#######....#...#
+#.###.#...#.#..####.###...#...###..#..#.#.#
+#.....#.####...###.##.######..#.#####.#...#
+#######.#.#.#.#.#.#.###.#.#.#.#.#.#.#.#####
+`
+
+func TestEncodeRectGolden(t *testing.T) {
+  version := rectVersions(7)[0]
+  encoded, err := encode([]Segment{{mode: Numeric, data: "123456"}}, version, CorrectionM, StructuredAppend{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if want := []byte{0x2C, 0x3D, 0xB9, 0x00, 0xEC, 0x11}; !bytes.Equal(encoded, want) {
+    t.Errorf("data codewords % X, want % X", encoded, want)
+  }
+  blocks := errorCorrection(encoded, version)
+  if want := []byte{0xBF, 0x8F, 0xE9, 0xF4, 0x9B, 0xFE, 0x1D}; !bytes.Equal(blocks[0].ec, want) {
+    t.Errorf("error correction % X, want % X", blocks[0].ec, want)
+  }
+
+  options := DefaultOptions()
+  options.MaxHeight = 7
+  symbol, err := Encode("123456", options)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !symbol.Rect || symbol.Version != 0 || symbol.Width != 43 || symbol.Height != 7 || symbol.Size != 0 || symbol.MaskScores != nil {
+    t.Errorf("got %+v", symbol)
+  }
+  if got := gridString(symbol.Modules); got != goldenR7x43 {
+    t.Errorf("got\n%swant\n%s", got, goldenR7x43)
+  }
+}
+
+func TestRectVersions(t *testing.T) {
+  all := listRectVersions()
+  if len(all) != 64 {
+    t.Fatalf("got %d rMQR versions, want 32 sizes at 2 levels", len(all))
+  }
+  for maxHeight := 7; maxHeight <= 17; maxHeight++ {
+    versions := rectVersions(maxHeight)
+    want := 0
+    for _, v := range all {
+      if v.height <= maxHeight {
+        want++
+      }
+    }
+    if len(versions) != want {
+      t.Errorf("height %d: got %d versions, want %d", maxHeight, len(versions), want)
+    }
+    for i, v := range versions {
+      if v.height > maxHeight {
+        t.Errorf("height %d: R%dx%d is too tall", maxHeight, v.height, v.width)
+      }
+      if i == 0 {
+        continue
+      }
+      //smallest area first, the shortest on ties
+      prev := versions[i-1]
+      area, prevArea := v.width*v.height, prev.width*prev.height
+      if area < prevArea || (area == prevArea && v.height < prev.height) {
+        t.Errorf("height %d: R%dx%d comes after R%dx%d", maxHeight, v.height, v.width, prev.height, prev.width)
+      }
+    }
+  }
+
+  sizes := func(versions []Version) string {
+    names := []string{}
+    for i := 0; i < len(versions); i += 2 {
+      names = append(names, fmt.Sprintf("R%dx%d", versions[i].height, versions[i].width))
+    }
+    return strings.Join(names, " ")
+  }
+  if got, want := sizes(rectVersions(7)), "R7x43 R7x59 R7x77 R7x99 R7x139"; got != want {
+    t.Errorf("height 7: got %s, want %s", got, want)
+  }
+  if got, want := sizes(rectVersions(9)), "R7x43 R9x43 R7x59 R9x59 R7x77 R7x99 R9x77 R9x99 R7x139 R9x139"; got != want {
+    t.Errorf("height 9: got %s, want %s", got, want)
+  }
+}
+
+func TestEncodeMaxHeight(t *testing.T) {
+  tests := []struct {
+    name string
+    data string
+    maxHeight int
+    level CorrectionLevel
+    height, width int
+  }{
+    {"shortest", "123456", 7, CorrectionM, 7, 43},
+    //R11x27 has a smaller area than R7x43
+    {"smallest area", "123456", 17, CorrectionM, 11, 27},
+    //R7x99 and R9x77 have the same area
+    {"shortest on ties", strings.Repeat("1", 50), 9, CorrectionM, 7, 99},
+    {"level H", "123456", 7, CorrectionH, 7, 59},
+  }
+  for _, test := range tests {
+    options := DefaultOptions()
+    options.MaxHeight = test.maxHeight
+    options.Level = test.level
+    symbol, err := Encode(test.data, options)
+    if err != nil {
+      t.Errorf("%s: %v", test.name, err)
+      continue
+    }
+    if symbol.Height != test.height || symbol.Width != test.width || symbol.Level != test.level {
+      t.Errorf("%s: got R%dx%d-%c, want R%dx%d", test.name, symbol.Height, symbol.Width, symbol.Level, test.height, test.width)
+    }
+  }
+
+  options := DefaultOptions()
+  options.MaxHeight = 7
+  var tooLong *DataTooLongError
+  if _, err := Encode(strings.Repeat("1", 103), options); !errors.As(err, &tooLong) {
+    t.Errorf("103 digits in R7x139-M: got %v, want a DataTooLongError", err)
+  }
+
+  for _, invalid := range []Options{
+    {Level: CorrectionM, Mask: AutoMask, MaxHeight: 6},
+    {Level: CorrectionM, Mask: AutoMask, MaxHeight: 18},
+    {Level: CorrectionM, Mask: AutoMask, MaxHeight: 7, Version: 1},
+    {Level: CorrectionM, Mask: AutoMask, MaxHeight: 7, Micro: true},
+  } {
+    if _, err := Encode("1", invalid); err != ErrInvalidHeight {
+      t.Errorf("%+v: got %v, want ErrInvalidHeight", invalid, err)
+    }
+  }
+}
@@ -0,0 +1,225 @@
+package qr
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Segment is a part of the input encoded in a single mode
+type Segment struct {
+  mode EncodingMode
+  data string
+}
+
+// segmentDataBits is the exact number of bits the data of a segment takes,
+// without the mode indicator and the character count
+func segmentDataBits(mode EncodingMode, count int) int {
+  switch mode {
+  case Numeric:
+    bits := (count/3) * 10
+    switch count % 3 {
+    case 1:
+      bits += 4
+    case 2:
+      bits += 7
+    }
+    return bits
+  case Alphanumeric:
+    return (count/2) * 11 + (count%2) * 6
+  case Byte:
+    return count * 8
+  case Kanji:
+    return count * 13
+  }
+  return 0
+}
+
+// segmentsBits is the total length of the segments in the given version,
+// including the mode indicator and character count of each one
+func segmentsBits(segments []Segment, version Version) int {
+  total := 0
+  for _, segment := range segments {
+    count := charCount(segment.data, segment.mode)
+    total += version.ModeIndicatorLength() + version.CharCountLength(segment.mode) + segmentDataBits(segment.mode, count)
+  }
+  return total
+}
+
+// optimizeSegments splits the input in the sequence of segments with the
+// smallest total bit length for the version (only the size of the character
+// counts depends on it).
+//
+// Costs are tracked in 1/6 bits so numeric (10/3 bits per digit) and
+// alphanumeric (11/2 bits per char) stay integers. For every character we
+// keep the cheapest cost of ending in each mode, then backtrack from the
+// cheapest final mode to find the mode of every character.
+// Micro versions lack some modes, it fails with an InvalidCharacterError
+// when a character fits in none of the version's modes
+func optimizeSegments(input string, version Version) ([]Segment, error) {
+  if len(input) == 0 {
+    return []Segment{}, nil
+  }
+
+  modes := []EncodingMode{Numeric, Alphanumeric, Byte, Kanji}
+  alphaTable := alphaTranslator()
+  chars := []rune(input)
+
+  var headCosts [4]int
+  var supported [4]bool
+  for i, mode := range modes {
+    headCosts[i] = (version.ModeIndicatorLength() + version.CharCountLength(mode)) * 6
+    supported[i] = version.supports(mode)
+  }
+
+  //charModes[i][m]: mode used for char i when the best path is in mode m after it, -1 when impossible
+  charModes := make([][4]int, len(chars))
+  prevCosts := headCosts
+  position := 0
+  for i, char := range chars {
+    curCosts := [4]int{math.MaxInt, math.MaxInt, math.MaxInt, math.MaxInt}
+    charModes[i] = [4]int{-1, -1, -1, -1}
+
+    //extend the segment in each possible mode
+    _, isAlpha := alphaTable[char]
+    fits := [4]bool{char >= '0' && char <= '9', isAlpha, true, isKanjiChar(char)}
+    extend := [4]int{20, 33, utf8.RuneLen(char) * 8 * 6, 78}
+    possible := false
+    for m := range modes {
+      if fits[m] && supported[m] {
+        curCosts[m] = prevCosts[m] + extend[m]
+        charModes[i][m] = m
+        possible = true
+      }
+    }
+    if !possible {
+      widest := Numeric
+      if supported[Alphanumeric] {
+        widest = Alphanumeric
+      }
+      return nil, &InvalidCharacterError{Char: char, Position: position, Mode: widest}
+    }
+    position += utf8.RuneLen(char)
+
+    //start a new segment after this char to switch modes
+    for to := range modes {
+      if !supported[to] {
+        continue
+      }
+      for from := range modes {
+        if charModes[i][from] == -1 {
+          continue
+        }
+        newCost := (curCosts[from] + 5) / 6 * 6 + headCosts[to]
+        if charModes[i][to] == -1 || newCost < curCosts[to] {
+          curCosts[to] = newCost
+          charModes[i][to] = from
+        }
+      }
+    }
+
+    prevCosts = curCosts
+  }
+
+  current := 0
+  for m := range modes {
+    if prevCosts[m] < prevCosts[current] {
+      current = m
+    }
+  }
+
+  charMode := make([]EncodingMode, len(chars))
+  for i := len(chars)-1; i >= 0; i-- {
+    current = charModes[i][current]
+    charMode[i] = EncodingMode(current)
+  }
+
+  segments := []Segment{}
+  start := 0
+  for i := 1; i <= len(chars); i++ {
+    if i == len(chars) || charMode[i] != charMode[start] {
+      segments = append(segments, splitSegment(Segment{mode: charMode[start], data: string(chars[start:i])}, version)...)
+      start = i
+    }
+  }
+
+  return segments, nil
+}
+
+// splitSegment breaks a segment whose character count doesn't fit in the
+// count indicator of the version
+func splitSegment(segment Segment, version Version) []Segment {
+  maxCount := 1 << version.CharCountLength(segment.mode) - 1
+  if charCount(segment.data, segment.mode) <= maxCount {
+    return []Segment{segment}
+  }
+
+  segments := []Segment{}
+  start := 0
+  for i, char := range segment.data {
+    if i > start && charCount(segment.data[start:i+utf8.RuneLen(char)], segment.mode) > maxCount {
+      segments = append(segments, Segment{mode: segment.mode, data: segment.data[start:i]})
+      start = i
+    }
+  }
+  segments = append(segments, Segment{mode: segment.mode, data: segment.data[start:]})
+
+  return segments
+}
+
+// determineSegmentedVersion finds the smallest version for the correction
+// level that fits the optimal segmentation of the input, trying the versions
+// in order
+func determineSegmentedVersion(input string, correction CorrectionLevel, versions []Version) (Version, []Segment, error) {
+  if !correction.valid() {
+    return Version{}, nil, ErrInvalidLevel
+  }
+
+  //the segmentation only depends on the widths of the indicators, shared
+  //by versions 1-9, 10-26 and 27-40, so it's computed once for each set
+  type widths [5]int
+  optimized := map[widths][]Segment{}
+  failed := map[widths]bool{}
+
+  var segments []Segment
+  var largest Version
+  for _, v := range versions {
+    if v.correction != correction {
+      continue
+    }
+    key := widths{v.ModeIndicatorLength(), v.CharCountLength(Numeric), v.CharCountLength(Alphanumeric), v.CharCountLength(Byte), v.CharCountLength(Kanji)}
+    if failed[key] {
+      continue
+    }
+    cached, ok := optimized[key]
+    if !ok {
+      var err error
+      cached, err = optimizeSegments(input, v)
+      if err != nil {
+        //a micro version without the modes for the input
+        failed[key] = true
+        continue
+      }
+      optimized[key] = cached
+    }
+    segments = cached
+    largest = v
+    if segmentsBits(segments, v) <= v.dataBits() {
+      return v, segments, nil
+    }
+  }
+
+  return Version{}, nil, &DataTooLongError{
+    Level: correction,
+    Modes: segmentModes(segments),
+    Bits: segmentsBits(segments, largest),
+    MaxBits: largest.dataBits(),
+  }
+}
+
+func segmentModes(segments []Segment) []EncodingMode {
+  modes := make([]EncodingMode, len(segments))
+  for i, segment := range segments {
+    modes[i] = segment.mode
+  }
+  return modes
+}
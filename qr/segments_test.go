@@ -0,0 +1,112 @@
+package qr
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestOptimizeSegmentsURL(t *testing.T) {
+  version, err := findVersion(2, CorrectionM, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  segments, err := optimizeSegments("HTTPS://EXAMPLE.COM/ORDER/0012345678901234", version)
+  if err != nil {
+    t.Fatal(err)
+  }
+  want := []Segment{{mode: Alphanumeric, data: "HTTPS://EXAMPLE.COM/ORDER/"}, {mode: Numeric, data: "0012345678901234"}}
+  if !reflect.DeepEqual(segments, want) {
+    t.Errorf("got %+v, want %+v", segments, want)
+  }
+  //4+9+143 alphanumeric and 4+10+54 numeric
+  if bits := segmentsBits(segments, version); bits != 224 {
+    t.Errorf("got %d bits, want 224", bits)
+  }
+}
+
+func TestOptimizeSegmentsLowercase(t *testing.T) {
+  version, err := findVersion(5, CorrectionM, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  //a single lowercase letter doesn't drag the rest into byte mode
+  segments, err := optimizeSegments("ABCDEFGHIJKLMNOPqRSTUVWXYZABCDEFGHIJ", version)
+  if err != nil {
+    t.Fatal(err)
+  }
+  want := []Segment{{mode: Alphanumeric, data: "ABCDEFGHIJKLMNOP"}, {mode: Byte, data: "q"}, {mode: Alphanumeric, data: "RSTUVWXYZABCDEFGHIJ"}}
+  if !reflect.DeepEqual(segments, want) {
+    t.Errorf("got %+v, want %+v", segments, want)
+  }
+}
+
+// bruteForceBits tries every mode for every character and returns the
+// shortest total length
+func bruteForceBits(chars []rune, version Version) int {
+  alphaTable := alphaTranslator()
+  best := -1
+  modes := make([]EncodingMode, len(chars))
+  var try func(i int)
+  try = func(i int) {
+    if i == len(chars) {
+      segments := []Segment{}
+      for j, char := range chars {
+        if j > 0 && modes[j] == modes[j-1] {
+          segments[len(segments)-1].data += string(char)
+          continue
+        }
+        segments = append(segments, Segment{mode: modes[j], data: string(char)})
+      }
+      if bits := segmentsBits(segments, version); best == -1 || bits < best {
+        best = bits
+      }
+      return
+    }
+    char := chars[i]
+    _, isAlpha := alphaTable[char]
+    fits := map[EncodingMode]bool{Numeric: char >= '0' && char <= '9', Alphanumeric: isAlpha, Byte: true, Kanji: isKanjiChar(char)}
+    for _, mode := range []EncodingMode{Numeric, Alphanumeric, Byte, Kanji} {
+      if fits[mode] && version.supports(mode) {
+        modes[i] = mode
+        try(i + 1)
+      }
+    }
+  }
+  try(0)
+  return best
+}
+
+func TestOptimizeSegmentsMinimal(t *testing.T) {
+  rng := rand.New(rand.NewSource(6))
+  alphabet := []rune("0123456789AZ $:a-漢字é")
+  versions := []Version{}
+  for _, find := range []struct {
+    nversion int
+    level CorrectionLevel
+    micro bool
+  }{{1, CorrectionL, false}, {10, CorrectionL, false}, {27, CorrectionL, false}, {3, CorrectionL, true}, {4, CorrectionL, true}} {
+    version, err := findVersion(find.nversion, find.level, find.micro)
+    if err != nil {
+      t.Fatal(err)
+    }
+    versions = append(versions, version)
+  }
+  versions = append(versions, rectVersions(17)[5])
+
+  for iteration := 0; iteration < 300; iteration++ {
+    chars := make([]rune, 1 + rng.Intn(8))
+    for i := range chars {
+      chars[i] = alphabet[rng.Intn(len(alphabet))]
+    }
+    for _, version := range versions {
+      segments, err := optimizeSegments(string(chars), version)
+      if err != nil {
+        t.Fatalf("%q: %v", string(chars), err)
+      }
+      if got, want := segmentsBits(segments, version), bruteForceBits(chars, version); got != want {
+        t.Errorf("%q in %+v: %d bits, the best is %d", string(chars), version, got, want)
+      }
+    }
+  }
+}
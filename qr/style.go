@@ -0,0 +1,199 @@
+package qr
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// ModuleShape is how data modules are drawn, the modules of function
+// patterns stay square so readers keep finding them
+type ModuleShape int
+const (
+  SquareModules ModuleShape = iota
+  // DotModules draws each dark module as a circle
+  DotModules
+  // RoundedModules draws each dark module as a square with rounded corners
+  RoundedModules
+  // LiquidModules rounds only the corners with no dark neighbours, so runs
+  // of modules flow into each other
+  LiquidModules
+)
+
+// FinderShape is how the parts of the three finder patterns are drawn
+type FinderShape int
+const (
+  SquareFinder FinderShape = iota
+  RoundedFinder
+  // CircleFinder is only allowed for the inner eye, a round outer ring
+  // isn't recognized by every reader
+  CircleFinder
+)
+
+// Style changes the look of the symbol. The zero value draws square modules
+// like the spec. The finder proportions (1:1:3:1:1) stay the same whatever
+// the shapes so readers still find them
+type Style struct {
+  Modules ModuleShape
+  // FinderRing is the outer 7x7 ring of the finders
+  FinderRing FinderShape
+  // FinderEye is the 3x3 center of the finders
+  FinderEye FinderShape
+  // RingColor and EyeColor default to the foreground when nil
+  RingColor color.Color
+  EyeColor color.Color
+}
+
+func (s Style) validate() error {
+  if s.Modules < SquareModules || s.Modules > LiquidModules {
+    return ErrInvalidStyle
+  }
+  if s.FinderRing != SquareFinder && s.FinderRing != RoundedFinder {
+    return ErrInvalidStyle
+  }
+  if s.FinderEye < SquareFinder || s.FinderEye > CircleFinder {
+    return ErrInvalidStyle
+  }
+  return nil
+}
+
+// checkContrast validates the finder colors against the background too
+func (s Style) checkContrast(colors Colors) error {
+  if err := colors.check(); err != nil {
+    return err
+  }
+  for _, c := range []color.Color{s.RingColor, s.EyeColor} {
+    if c == nil || colors.MinContrast <= 0 {
+      continue
+    }
+    if err := CheckContrast(c, colors.background(), colors.MinContrast); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// corner radii of a rounded rectangle: top left, top right, bottom right,
+// bottom left
+type radii [4]float64
+
+// finderOrigins are the top left corners of the finder patterns, Micro
+// and rMQR symbols only have the top left one
+func (s *Symbol) finderOrigins() [][2]int {
+  if s.Micro || s.Rect {
+    return [][2]int{{0, 0}}
+  }
+  return [][2]int{{0, 0}, {0, s.Size-7}, {s.Size-7, 0}}
+}
+
+// finderOrigin returns the top left corner of the finder pattern the module
+// belongs to, ok is false for modules outside of the finders
+func finderOrigin(symbol *Symbol, row, col int) (int, int, bool) {
+  for _, origin := range symbol.finderOrigins() {
+    if row >= origin[0] && row < origin[0]+7 && col >= origin[1] && col < origin[1]+7 {
+      return origin[0], origin[1], true
+    }
+  }
+  return 0, 0, false
+}
+
+// moduleRadii gives the corners of the dark module at row, col, function
+// modules are square
+func moduleRadii(symbol *Symbol, shape ModuleShape, row, col int) radii {
+  if symbol.Function != nil && symbol.Function[row][col] {
+    return radii{}
+  }
+  switch shape {
+  case DotModules:
+    return radii{0.5, 0.5, 0.5, 0.5}
+  case RoundedModules:
+    return radii{0.25, 0.25, 0.25, 0.25}
+  case LiquidModules:
+    dark := func(r, c int) bool {
+      return r >= 0 && c >= 0 && r < symbol.Height && c < symbol.Width && symbol.Modules[r][c]
+    }
+    //a corner is round when both of its sides are open
+    corner := func(vertical, horizontal bool) float64 {
+      if vertical || horizontal {
+        return 0
+      }
+      return 0.5
+    }
+    up, down, left, right := dark(row-1, col), dark(row+1, col), dark(row, col-1), dark(row, col+1)
+    return radii{corner(up, left), corner(up, right), corner(down, right), corner(down, left)}
+  }
+  return radii{}
+}
+
+// finderRadii gives the corners of the outer edge of the ring, its inner
+// edge and the eye
+func finderRadii(ring, eye FinderShape) (radii, radii, radii) {
+  var outer, inner, center radii
+  if ring == RoundedFinder {
+    outer = radii{2, 2, 2, 2}
+    inner = radii{1, 1, 1, 1}
+  }
+  switch eye {
+  case RoundedFinder:
+    center = radii{0.75, 0.75, 0.75, 0.75}
+  case CircleFinder:
+    center = radii{1.5, 1.5, 1.5, 1.5}
+  }
+  return outer, inner, center
+}
+
+// insideRoundedRect tells if the point x, y is in the w by h rectangle at
+// the origin with the given corner radii
+func insideRoundedRect(x, y, w, h float64, r radii) bool {
+  if x < 0 || y < 0 || x >= w || y >= h {
+    return false
+  }
+  //each corner: center of its arc and the quadrant it covers
+  corners := [4][2]float64{{r[0], r[0]}, {w - r[1], r[1]}, {w - r[2], h - r[2]}, {r[3], h - r[3]}}
+  for i, center := range corners {
+    if r[i] == 0 {
+      continue
+    }
+    inCornerX := (i == 0 || i == 3) && x < center[0] || (i == 1 || i == 2) && x > center[0]
+    inCornerY := (i == 0 || i == 1) && y < center[1] || (i == 2 || i == 3) && y > center[1]
+    if inCornerX && inCornerY && math.Hypot(x-center[0], y-center[1]) > r[i] {
+      return false
+    }
+  }
+  return true
+}
+
+// roundedRectPath is the SVG path of the rectangle, drawn clockwise or
+// counterclockwise (to cut holes with the nonzero fill rule)
+func roundedRectPath(x, y, w, h float64, r radii, clockwise bool) string {
+  num := func(v float64) string {
+    if v == 0 {
+      //no -0
+      v = 0
+    }
+    return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.3f", v), "0"), ".")
+  }
+  arc := func(radius, dx, dy float64, sweep int) string {
+    if radius == 0 {
+      return ""
+    }
+    return fmt.Sprintf("a%s %s 0 0 %d %s %s", num(radius), num(radius), sweep, num(dx), num(dy))
+  }
+
+  var sb strings.Builder
+  if clockwise {
+    fmt.Fprintf(&sb, "M%s %s", num(x+r[0]), num(y))
+    fmt.Fprintf(&sb, "h%s%s", num(w-r[0]-r[1]), arc(r[1], r[1], r[1], 1))
+    fmt.Fprintf(&sb, "v%s%s", num(h-r[1]-r[2]), arc(r[2], -r[2], r[2], 1))
+    fmt.Fprintf(&sb, "h%s%s", num(-(w-r[2]-r[3])), arc(r[3], -r[3], -r[3], 1))
+    fmt.Fprintf(&sb, "v%s%sz", num(-(h-r[3]-r[0])), arc(r[0], r[0], -r[0], 1))
+  } else {
+    fmt.Fprintf(&sb, "M%s %s", num(x+r[0]), num(y))
+    fmt.Fprintf(&sb, "%sv%s", arc(r[0], -r[0], r[0], 0), num(h-r[0]-r[3]))
+    fmt.Fprintf(&sb, "%sh%s", arc(r[3], r[3], r[3], 0), num(w-r[3]-r[2]))
+    fmt.Fprintf(&sb, "%sv%s", arc(r[2], r[2], -r[2], 0), num(-(h-r[2]-r[1])))
+    fmt.Fprintf(&sb, "%sz", arc(r[1], -r[1], -r[1], 0))
+  }
+  return sb.String()
+}
@@ -0,0 +1,47 @@
+package qr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFunctionModulesSquare(t *testing.T) {
+  options := DefaultOptions()
+  options.Version = 2
+  symbol, err := Encode("HELLO", options)
+  if err != nil {
+    t.Fatal(err)
+  }
+  //the alignment pattern of version 2 is centered at (18, 18)
+  if !symbol.Function[18][18] || !symbol.Modules[18][18] {
+    t.Fatal("(18, 18) isn't the dark center of the alignment pattern")
+  }
+
+  pngOptions := PNGOptions{ModuleSize: 10, Colors: DefaultColors(), Style: Style{Modules: DotModules}}
+  img := symbol.Image(pngOptions)
+  //a dot leaves the corner pixels light, a square fills them
+  corner := func(row, col int) uint8 {
+    return img.ColorIndexAt(col*10, row*10)
+  }
+  for _, module := range [][2]int{{16, 16}, {16, 20}, {20, 16}, {20, 20}, {18, 18}} {
+    if corner(module[0], module[1]) != 1 {
+      t.Errorf("alignment module %v is drawn as a dot", module)
+    }
+  }
+  //data modules are still dots
+  for row := 0; row < symbol.Height; row++ {
+    for col := 0; col < symbol.Width; col++ {
+      if symbol.Modules[row][col] && !symbol.Function[row][col] && corner(row, col) != 0 {
+        t.Fatalf("data module (%d, %d) is drawn square", row, col)
+      }
+    }
+  }
+
+  path := svgPath(symbol, 0, DotModules, LogoArea{})
+  if !strings.Contains(path, "M18 18h1v1h-1v-1z") {
+    t.Error("the svg path doesn't draw (18, 18) square")
+  }
+  if strings.Count(path, "M18 18") != 1 {
+    t.Error("the svg path draws (18, 18) more than once")
+  }
+}
@@ -0,0 +1,157 @@
+package qr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// SVGOptions configures WriteSVG
+type SVGOptions struct {
+  // QuietZone is the margin in modules, see DefaultQuietZone
+  QuietZone int
+  Colors
+  Style
+  // Logo is embedded as a PNG over the symbol's logo area, if any
+  Logo image.Image
+  // Width sets the width attribute in pixels and the height in proportion,
+  // when 0 they are left out and the image scales to its container through
+  // the viewBox
+  Width int
+}
+
+// DefaultSVGOptions draws black on white with DefaultQuietZone and no
+// fixed size
+func DefaultSVGOptions() SVGOptions {
+  return SVGOptions{QuietZone: DefaultQuietZone, Colors: DefaultColors()}
+}
+
+// svgFill is the fill attribute for the color, with its opacity when it's
+// translucent
+func svgFill(c color.Color) string {
+  hex, opacity := cssColor(c)
+  if opacity < 1 {
+    return fmt.Sprintf(`fill="%s" fill-opacity="%.3g"`, hex, opacity)
+  }
+  return fmt.Sprintf(`fill="%s"`, hex)
+}
+
+// svgPath builds the path data for the dark modules outside of the finders.
+// With square modules each horizontal run of dark modules is a single
+// rectangle, other shapes get a subpath per module. Coordinates are in
+// modules, the ones in skip are left out
+func svgPath(symbol *Symbol, quietZone int, shape ModuleShape, skip LogoArea) string {
+  dark := func(row, col int) bool {
+    _, _, finder := finderOrigin(symbol, row, col)
+    return symbol.Modules[row][col] && !finder && !skip.contains(row, col)
+  }
+
+  var sb strings.Builder
+  for row := 0; row < symbol.Height; row++ {
+    for col := 0; col < symbol.Width; col++ {
+      if !dark(row, col) {
+        continue
+      }
+      if shape != SquareModules {
+        corners := moduleRadii(symbol, shape, row, col)
+        sb.WriteString(roundedRectPath(float64(col+quietZone), float64(row+quietZone), 1, 1, corners, true))
+        continue
+      }
+      start := col
+      for col < symbol.Width && dark(row, col) {
+        col++
+      }
+      length := col - start
+      fmt.Fprintf(&sb, "M%d %dh%dv1h-%dz", start+quietZone, row+quietZone, length, length)
+    }
+  }
+  return sb.String()
+}
+
+// svgFinders builds the path data of the finder rings and eyes, each ring is
+// its outer edge with the inner one drawn the other way around as a hole
+func svgFinders(symbol *Symbol, quietZone int, style Style) (string, string) {
+  outer, inner, center := finderRadii(style.FinderRing, style.FinderEye)
+  var rings, eyes strings.Builder
+  for _, origin := range symbol.finderOrigins() {
+    x := float64(origin[1] + quietZone)
+    y := float64(origin[0] + quietZone)
+    rings.WriteString(roundedRectPath(x, y, 7, 7, outer, true))
+    rings.WriteString(roundedRectPath(x+1, y+1, 5, 5, inner, false))
+    eyes.WriteString(roundedRectPath(x+2, y+2, 3, 3, center, true))
+  }
+  return rings.String(), eyes.String()
+}
+
+// WriteSVG writes the symbol as an SVG document with one rectangle for the
+// background (left out when it's transparent) and a single path for all the
+// dark modules. Finder rings and eyes with their own color get a path each
+func WriteSVG(w io.Writer, symbol *Symbol, options SVGOptions) error {
+  if options.QuietZone < 0 || options.Width < 0 {
+    return ErrInvalidImageOptions
+  }
+  if err := options.Style.validate(); err != nil {
+    return err
+  }
+  if err := options.checkContrast(options.Colors); err != nil {
+    return err
+  }
+  logo := LogoArea{}
+  if options.Logo != nil {
+    if symbol.Logo.Size == 0 {
+      return ErrNoLogoArea
+    }
+    logo = symbol.Logo
+  }
+
+  width := symbol.Width + 2*options.QuietZone
+  height := symbol.Height + 2*options.QuietZone
+  size := ""
+  if options.Width > 0 {
+    size = fmt.Sprintf(` width="%d" height="%d"`, options.Width, options.Width * height / width)
+  }
+
+  var sb strings.Builder
+  sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+  //crisp edges only suit straight lines, curves would look jagged
+  rendering := "crispEdges"
+  if options.Modules != SquareModules || options.FinderRing != SquareFinder || options.FinderEye != SquareFinder {
+    rendering = "geometricPrecision"
+  }
+  fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d"%s shape-rendering="%s">`+"\n", width, height, size, rendering)
+  if _, _, _, a := options.background().RGBA(); a > 0 {
+    fmt.Fprintf(&sb, `<rect width="%d" height="%d" %s/>`+"\n", width, height, svgFill(options.background()))
+  }
+  path := svgPath(symbol, options.QuietZone, options.Modules, logo)
+  rings, eyes := svgFinders(symbol, options.QuietZone, options.Style)
+  if options.RingColor == nil {
+    path += rings
+  }
+  if options.EyeColor == nil {
+    path += eyes
+  }
+  fmt.Fprintf(&sb, `<path d="%s" %s/>`+"\n", path, svgFill(options.foreground()))
+  if options.RingColor != nil {
+    fmt.Fprintf(&sb, `<path d="%s" %s/>`+"\n", rings, svgFill(options.RingColor))
+  }
+  if options.EyeColor != nil {
+    fmt.Fprintf(&sb, `<path d="%s" %s/>`+"\n", eyes, svgFill(options.EyeColor))
+  }
+  if options.Logo != nil {
+    var encoded bytes.Buffer
+    if err := png.Encode(&encoded, options.Logo); err != nil {
+      return err
+    }
+    fmt.Fprintf(&sb, `<image x="%d" y="%d" width="%d" height="%d" href="data:image/png;base64,%s"/>`+"\n",
+      logo.Col+options.QuietZone, logo.Row+options.QuietZone, logo.Size, logo.Size, base64.StdEncoding.EncodeToString(encoded.Bytes()))
+  }
+  sb.WriteString("</svg>\n")
+
+  _, err := io.WriteString(w, sb.String())
+  return err
+}
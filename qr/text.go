@@ -0,0 +1,73 @@
+package qr
+
+import (
+	"strings"
+)
+
+// TextOptions configures RenderText
+type TextOptions struct {
+  // QuietZone is the margin in modules, see DefaultQuietZone
+  QuietZone int
+  // Invert draws the light modules instead of the dark ones, for terminals
+  // with light text on a dark background
+  Invert bool
+  // ASCII uses "##" and spaces, one line per row, instead of half blocks
+  ASCII bool
+}
+
+// DefaultTextOptions uses half blocks for dark on light terminals with
+// DefaultQuietZone
+func DefaultTextOptions() TextOptions {
+  return TextOptions{QuietZone: DefaultQuietZone}
+}
+
+// RenderText draws the symbol with text characters. With half blocks each
+// line holds two rows of modules (▀ top, ▄ bottom, █ both)
+func RenderText(symbol *Symbol, options TextOptions) string {
+  width := symbol.Width + 2*options.QuietZone
+  height := symbol.Height + 2*options.QuietZone
+  //drawn tells if the module goes as a block, rows past the end are never drawn
+  drawn := func(row, col int) bool {
+    if row >= height {
+      return false
+    }
+    row -= options.QuietZone
+    col -= options.QuietZone
+    dark := row >= 0 && col >= 0 && row < symbol.Height && col < symbol.Width && symbol.Modules[row][col]
+    return dark != options.Invert
+  }
+
+  var sb strings.Builder
+  if options.ASCII {
+    for row := 0; row < height; row++ {
+      for col := 0; col < width; col++ {
+        if drawn(row, col) {
+          sb.WriteString("##")
+        } else {
+          sb.WriteString("  ")
+        }
+      }
+      sb.WriteString("\n")
+    }
+    return sb.String()
+  }
+
+  for row := 0; row < height; row += 2 {
+    for col := 0; col < width; col++ {
+      top := drawn(row, col)
+      bottom := drawn(row+1, col)
+      switch {
+      case top && bottom:
+        sb.WriteString("█")
+      case top:
+        sb.WriteString("▀")
+      case bottom:
+        sb.WriteString("▄")
+      default:
+        sb.WriteString(" ")
+      }
+    }
+    sb.WriteString("\n")
+  }
+  return sb.String()
+}